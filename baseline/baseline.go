@@ -0,0 +1,160 @@
+// Package baseline records existing loglint findings so that only new findings are reported.
+//
+// Findings are keyed by file, rule and a fingerprint of the message and the
+// normalized source line, so entries survive unrelated edits that shift lines.
+package baseline
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// version is the current baseline file format version.
+const version = 1
+
+// Finding is a diagnostic reported by loglint.
+type Finding struct {
+	// File is the slash-separated path of the file, relative to the baseline root.
+	File   string
+	Line   int
+	Column int
+	Rule   string
+	// Severity is the severity level of the finding: error, warning or info.
+	// It is not part of the fingerprint, so changing the severity of a rule
+	// keeps its baseline entries.
+	Severity string
+	// Message is the diagnostic message without the severity prefix.
+	Message string
+	// Source is the text of the source line of the finding.
+	Source string
+}
+
+// Entry is a group of identical findings recorded in a baseline.
+type Entry struct {
+	File        string `json:"file"`
+	Rule        string `json:"rule"`
+	Message     string `json:"message"`
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+// Baseline is the content of a baseline file.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Fingerprint returns the line-independent fingerprint of a finding.
+func Fingerprint(f Finding) string {
+	h := sha256.New()
+	for _, part := range []string{f.Rule, normalize(f.Message), normalize(f.Source)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// normalize collapses all whitespace runs into single spaces.
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+type key struct {
+	file, rule, fingerprint string
+}
+
+func keyOf(f Finding) key {
+	return key{file: f.File, rule: f.Rule, fingerprint: Fingerprint(f)}
+}
+
+// New creates a baseline containing the findings.
+func New(findings []Finding) *Baseline {
+	index := make(map[key]int)
+	b := &Baseline{Version: version}
+	for _, f := range findings {
+		k := keyOf(f)
+		if i, ok := index[k]; ok {
+			b.Entries[i].Count++
+			continue
+		}
+		index[k] = len(b.Entries)
+		b.Entries = append(b.Entries, Entry{
+			File:        f.File,
+			Rule:        f.Rule,
+			Message:     f.Message,
+			Fingerprint: k.fingerprint,
+			Count:       1,
+		})
+	}
+
+	slices.SortFunc(b.Entries, func(x, y Entry) int {
+		return cmp.Or(
+			cmp.Compare(x.File, y.File),
+			cmp.Compare(x.Rule, y.Rule),
+			cmp.Compare(x.Message, y.Message),
+			cmp.Compare(x.Fingerprint, y.Fingerprint),
+		)
+	})
+	return b
+}
+
+// Load reads a baseline file.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+	if b.Version != version {
+		return nil, fmt.Errorf("baseline %s: unsupported version %d", path, b.Version)
+	}
+	return &b, nil
+}
+
+// Write writes the baseline to a file.
+func (b *Baseline) Write(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Filter returns the findings that are not recorded in the baseline and the
+// baseline entries that no longer occur. The Count of a fixed entry is the
+// number of findings that disappeared.
+func (b *Baseline) Filter(findings []Finding) (added []Finding, fixed []Entry) {
+	remaining := make(map[key]int, len(b.Entries))
+	for _, e := range b.Entries {
+		remaining[key{file: e.File, rule: e.Rule, fingerprint: e.Fingerprint}] += e.Count
+	}
+
+	for _, f := range findings {
+		k := keyOf(f)
+		if remaining[k] > 0 {
+			remaining[k]--
+			continue
+		}
+		added = append(added, f)
+	}
+
+	for _, e := range b.Entries {
+		k := key{file: e.File, rule: e.Rule, fingerprint: e.Fingerprint}
+		if n := remaining[k]; n > 0 {
+			e.Count = min(n, e.Count)
+			remaining[k] -= e.Count
+			fixed = append(fixed, e)
+		}
+	}
+	return added, fixed
+}
@@ -0,0 +1,151 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func finding(file string, line int, rule, source string) Finding {
+	return Finding{
+		File:     file,
+		Line:     line,
+		Column:   2,
+		Rule:     rule,
+		Severity: "error",
+		Message:  "log message should start with a lowercase letter",
+		Source:   source,
+	}
+}
+
+func TestFingerprintIgnoresLineAndWhitespace(t *testing.T) {
+	a := finding("main.go", 10, "lowercase", `	slog.Info("Starting server")`)
+	b := finding("main.go", 42, "lowercase", `    slog.Info("Starting server")   `)
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("fingerprint should not depend on the line number or indentation")
+	}
+
+	c := finding("main.go", 10, "lowercase", `slog.Info("Stopping server")`)
+	if Fingerprint(a) == Fingerprint(c) {
+		t.Error("fingerprint should depend on the source line")
+	}
+
+	d := finding("main.go", 10, "nospecial", `slog.Info("Starting server")`)
+	if Fingerprint(a) == Fingerprint(d) {
+		t.Error("fingerprint should depend on the rule")
+	}
+}
+
+func TestFingerprintIgnoresSeverity(t *testing.T) {
+	a := finding("main.go", 10, "lowercase", `slog.Info("Starting server")`)
+	b := a
+	b.Severity = "warning"
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("fingerprint should not depend on the severity")
+	}
+
+	added, _ := New([]Finding{a}).Filter([]Finding{b})
+	if len(added) != 0 {
+		t.Errorf("changing the severity made findings new: %+v", added)
+	}
+}
+
+func TestNewGroupsDuplicates(t *testing.T) {
+	b := New([]Finding{
+		finding("b.go", 1, "lowercase", `slog.Info("Starting")`),
+		finding("a.go", 1, "lowercase", `slog.Info("Starting")`),
+		finding("a.go", 7, "lowercase", `slog.Info("Starting")`),
+	})
+
+	if len(b.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(b.Entries))
+	}
+	if b.Entries[0].File != "a.go" || b.Entries[0].Count != 2 {
+		t.Errorf("unexpected first entry: %+v", b.Entries[0])
+	}
+	if b.Entries[1].File != "b.go" || b.Entries[1].Count != 1 {
+		t.Errorf("unexpected second entry: %+v", b.Entries[1])
+	}
+}
+
+func TestFilter(t *testing.T) {
+	b := New([]Finding{
+		finding("a.go", 10, "lowercase", `slog.Info("Starting")`),
+		finding("a.go", 20, "lowercase", `slog.Info("Stopping")`),
+		finding("a.go", 30, "lowercase", `slog.Info("Stopping")`),
+	})
+
+	// lines shifted, one "Stopping" fixed, one new finding added
+	added, fixed := b.Filter([]Finding{
+		finding("a.go", 15, "lowercase", `slog.Info("Starting")`),
+		finding("a.go", 25, "lowercase", `slog.Info("Stopping")`),
+		finding("a.go", 40, "lowercase", `slog.Info("Restarting")`),
+	})
+
+	if len(added) != 1 || added[0].Line != 40 {
+		t.Errorf("expected only the finding on line 40 to be new, got %+v", added)
+	}
+	if len(fixed) != 1 || fixed[0].Count != 1 {
+		t.Errorf("expected one fixed Stopping finding, got %+v", fixed)
+	}
+}
+
+func TestFilterAdditionalDuplicate(t *testing.T) {
+	b := New([]Finding{
+		finding("a.go", 10, "lowercase", `slog.Info("Starting")`),
+	})
+
+	added, fixed := b.Filter([]Finding{
+		finding("a.go", 10, "lowercase", `slog.Info("Starting")`),
+		finding("a.go", 20, "lowercase", `slog.Info("Starting")`),
+	})
+
+	if len(added) != 1 {
+		t.Errorf("expected the second identical finding to be new, got %+v", added)
+	}
+	if len(fixed) != 0 {
+		t.Errorf("expected no fixed entries, got %+v", fixed)
+	}
+}
+
+func TestWriteLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	b := New([]Finding{finding("a.go", 10, "lowercase", `slog.Info("Starting")`)})
+
+	if err := b.Write(path); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0] != b.Entries[0] {
+		t.Errorf("loaded entries %+v, want %+v", loaded.Entries, b.Entries)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected error for missing file")
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(invalid); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+
+	unsupported := filepath.Join(dir, "unsupported.json")
+	if err := os.WriteFile(unsupported, []byte(`{"version": 99}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(unsupported); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+}
@@ -1,11 +1,24 @@
 package main
 
 import (
+	"flag"
+	"os"
+
 	loglint "github.com/RomanKovalev007/log_linter/loglint"
 
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/analysis/multichecker"
 )
 
 func main() {
-	singlechecker.Main(loglint.Analyzer)
+	// the multichecker exits with status 3 on any finding, so it only runs
+	// the modes loglint does not implement, such as -fix
+	if !multicheckerRequested(os.Args[1:]) {
+		os.Exit(run(os.Args[1:]))
+	}
+
+	// -config is shared by all rules; it is an alias of -logcalls.config.
+	config := loglint.Detector.Flags.Lookup("config")
+	flag.Var(config.Value, config.Name, config.Usage)
+
+	multichecker.Main(loglint.Analyzers...)
 }
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/RomanKovalev007/log_linter/baseline"
+	loglint "github.com/RomanKovalev007/log_linter/loglint"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// multicheckerFlags select the modes run by the multichecker driver: applying
+// fixes, JSON output and the go vet protocol. Findings do not affect the exit
+// status in these modes, so it does not depend on the severity there either.
+var multicheckerFlags = []string{"fix", "diff", "json", "V", "flags"}
+
+// multicheckerRequested reports whether the command line uses a flag that
+// only the multichecker driver supports.
+func multicheckerRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != arg && slices.Contains(multicheckerFlags, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// run runs the analyzers and prints their findings. With -baseline it prints
+// only the findings missing from the baseline file, and with -update-baseline
+// it records the current findings instead. The exit status is 3 if a finding
+// of error severity is printed; warning and info findings do not fail the run.
+func run(args []string) int {
+	fs := flag.NewFlagSet("loglint", flag.ExitOnError)
+	path := fs.String("baseline", "", "path to the baseline file")
+	update := fs.Bool("update-baseline", false, "write current findings to the baseline file")
+	tests := fs.Bool("test", true, "indicates whether test files should be analyzed, too")
+	config := loglint.Detector.Flags.Lookup("config")
+	fs.Var(config.Value, config.Name, config.Usage)
+	fs.Var(config.Value, loglint.Detector.Name+"."+config.Name, config.Usage)
+
+	enabled := enableFlags(fs)
+	_ = fs.Parse(args)
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 1
+	}
+	if *update && *path == "" {
+		fmt.Fprintln(os.Stderr, "loglint: -update-baseline requires -baseline")
+		return 2
+	}
+
+	findings, err := analyze(selectAnalyzers(fs, enabled), *tests, fs.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loglint: %v\n", err)
+		return 1
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "loglint: %v\n", err)
+		return 1
+	}
+
+	// baseline entries are relative to the directory of the baseline file,
+	// so the same baseline matches wherever loglint is run from
+	root := wd
+	if *path != "" {
+		abs, err := filepath.Abs(*path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "loglint: %v\n", err)
+			return 1
+		}
+		root = filepath.Dir(abs)
+	}
+	for i := range findings {
+		findings[i].File = relative(root, findings[i].File)
+	}
+
+	if *update {
+		if err := baseline.New(findings).Write(*path); err != nil {
+			fmt.Fprintf(os.Stderr, "loglint: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "loglint: wrote %d findings to %s\n", len(findings), *path)
+		return 0
+	}
+
+	if *path != "" {
+		b, err := baseline.Load(*path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "loglint: %v\n", err)
+			return 1
+		}
+
+		var fixed []baseline.Entry
+		findings, fixed = b.Filter(findings)
+		for _, e := range fixed {
+			fmt.Fprintf(os.Stderr, "loglint: fixed baseline entry: %s: %s (%s, %d)\n", e.File, e.Message, e.Rule, e.Count)
+		}
+		if len(fixed) > 0 {
+			fmt.Fprintf(os.Stderr, "loglint: run with -update-baseline to remove fixed entries from %s\n", *path)
+		}
+	}
+
+	failed := false
+	for _, f := range findings {
+		file := f.File
+		if !filepath.IsAbs(filepath.FromSlash(file)) {
+			file = relative(wd, filepath.Join(root, filepath.FromSlash(file)))
+		}
+		msg := f.Message
+		if f.Severity != "error" {
+			msg = f.Severity + ": " + msg
+		}
+		fmt.Fprintf(os.Stderr, "%s:%d:%d: %s\n", file, f.Line, f.Column, msg)
+		failed = failed || f.Severity == "error"
+	}
+	if failed {
+		return 3
+	}
+	return 0
+}
+
+// enableFlags registers a flag per analyzer to enable or disable it.
+func enableFlags(fs *flag.FlagSet) map[string]*bool {
+	enabled := make(map[string]*bool, len(loglint.Analyzers))
+	for _, a := range loglint.Analyzers {
+		enabled[a.Name] = fs.Bool(a.Name, true, "enable "+a.Name+" analysis")
+	}
+	return enabled
+}
+
+// selectAnalyzers returns the analyzers enabled by the command line, as the
+// multichecker does: if some analyzers are enabled explicitly only they run,
+// otherwise all run except the ones disabled.
+func selectAnalyzers(fs *flag.FlagSet, enabled map[string]*bool) []*analysis.Analyzer {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		if p, ok := enabled[f.Name]; ok && *p {
+			explicit[f.Name] = true
+		}
+	})
+
+	var analyzers []*analysis.Analyzer
+	for _, a := range loglint.Analyzers {
+		if explicit[a.Name] || (len(explicit) == 0 && *enabled[a.Name]) {
+			analyzers = append(analyzers, a)
+		}
+	}
+	return analyzers
+}
+
+// analyze loads the packages matching patterns and returns the findings of the
+// analyzers with absolute file names and messages without the severity prefix.
+func analyze(analyzers []*analysis.Analyzer, tests bool, patterns []string) ([]baseline.Finding, error) {
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Tests: tests}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("failed to load packages")
+	}
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// test variants of a package repeat the diagnostics of its files
+	seen := make(map[string]bool)
+	sources := make(map[string][]string)
+	var findings []baseline.Finding
+	for act := range graph.All() {
+		if !act.IsRoot {
+			continue
+		}
+		if act.Err != nil {
+			return nil, fmt.Errorf("%s: %w", act, act.Err)
+		}
+
+		for _, d := range act.Diagnostics {
+			posn := act.Package.Fset.Position(d.Pos)
+			id := fmt.Sprintf("%s:%d:%d:%s:%s", posn.Filename, posn.Line, posn.Column, d.Category, d.Message)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			severity := loglint.Severity(d)
+			findings = append(findings, baseline.Finding{
+				File:     posn.Filename,
+				Line:     posn.Line,
+				Column:   posn.Column,
+				Rule:     loglint.Rule(d),
+				Severity: severity,
+				Message:  strings.TrimPrefix(d.Message, severity+": "),
+				Source:   sourceLine(sources, posn.Filename, posn.Line),
+			})
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings, nil
+}
+
+// relative returns the slash-separated path of file relative to dir,
+// or file itself if it is outside dir.
+func relative(dir, file string) string {
+	rel, err := filepath.Rel(dir, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// sourceLine returns the text of a line of a file, caching file contents in sources.
+func sourceLine(sources map[string][]string, filename string, line int) string {
+	lines, ok := sources[filename]
+	if !ok {
+		data, _ := os.ReadFile(filename)
+		lines = strings.Split(string(data), "\n")
+		sources[filename] = lines
+	}
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	return lines[line-1]
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/RomanKovalev007/log_linter/baseline"
+	loglint "github.com/RomanKovalev007/log_linter/loglint"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestMulticheckerRequested(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"./..."}, false},
+		{[]string{"-fix", "./..."}, true},
+		{[]string{"--json", "./..."}, true},
+		{[]string{"-diff=true", "-fix", "./..."}, true},
+		{[]string{"-baseline", "b.json", "./..."}, false},
+		{[]string{"-lowercase=false", "./..."}, false},
+		{[]string{"--", "-fix"}, false},
+		{[]string{"fix"}, false},
+	}
+	for _, tt := range tests {
+		if got := multicheckerRequested(tt.args); got != tt.want {
+			t.Errorf("multicheckerRequested(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestSelectAnalyzers(t *testing.T) {
+	tests := []struct {
+		args    []string
+		include []string
+		exclude []string
+	}{
+		{nil, []string{"lowercase", "nospecial", "sensitivedata"}, nil},
+		{[]string{"-sensitivedata"}, []string{"sensitivedata"}, []string{"lowercase", "nospecial"}},
+		{[]string{"-lowercase=false", "-nospecial=false"}, []string{"sensitivedata"}, []string{"lowercase", "nospecial"}},
+		{[]string{"-sensitivedata", "-lowercase=false"}, []string{"sensitivedata"}, []string{"lowercase", "nospecial"}},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("loglint", flag.ContinueOnError)
+		enabled := enableFlags(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+		for _, a := range selectAnalyzers(fs, enabled) {
+			names = append(names, a.Name)
+		}
+		for _, name := range tt.include {
+			if !slices.Contains(names, name) {
+				t.Errorf("%q: %s is not selected: %v", tt.args, name, names)
+			}
+		}
+		for _, name := range tt.exclude {
+			if slices.Contains(names, name) {
+				t.Errorf("%q: %s is selected: %v", tt.args, name, names)
+			}
+		}
+	}
+}
+
+// writeModule creates a module with a file logging two messages that start
+// with a capital letter, one of them with a special character, and changes
+// into its directory.
+func writeModule(t *testing.T, config string) {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"main.go": `package main
+
+import "log/slog"
+
+func main() {
+	slog.Info("Starting server")
+	slog.Info("server started!")
+}
+`,
+		".loglint.yml": config,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+}
+
+func TestRunExitStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   int
+	}{
+		{"error", "", 3},
+		{"warning", "severity:\n  lowercase: warning\n  no_special_chars: warning\n", 0},
+		{"info", "severity:\n  lowercase: info\n  no_special_chars: info\n", 0},
+		{"error and warning", "severity:\n  lowercase: warning\n", 3},
+		{"no findings", "rules:\n  lowercase: false\n  no_special_chars: false\n", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeModule(t, tt.config)
+			if got := run([]string{"./..."}); got != tt.want {
+				t.Errorf("exit status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeSeverity(t *testing.T) {
+	writeModule(t, "severity:\n  lowercase: info\n")
+	analyzers := []*analysis.Analyzer{loglint.LowercaseAnalyzer, loglint.NoSpecialAnalyzer}
+	findings, err := analyze(analyzers, true, []string{"./..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+	for _, f := range findings {
+		got[f.Rule] = f.Severity
+	}
+	if got["lowercase"] != "info" || got["nospecial"] != "error" {
+		t.Errorf("severities = %v, want lowercase info and nospecial error", got)
+	}
+}
+
+func TestRunUpdateBaselineRequiresPath(t *testing.T) {
+	writeModule(t, "")
+	if got := run([]string{"-update-baseline", "./..."}); got != 2 {
+		t.Errorf("exit status = %d, want 2", got)
+	}
+}
+
+func TestRunBaseline(t *testing.T) {
+	writeModule(t, "")
+	if got := run([]string{"-baseline", "baseline.json", "-update-baseline", "./..."}); got != 0 {
+		t.Fatalf("updating the baseline: exit status = %d, want 0", got)
+	}
+	if got := run([]string{"-baseline", "baseline.json", "./..."}); got != 0 {
+		t.Errorf("exit status with all findings in the baseline = %d, want 0", got)
+	}
+
+	// lowering the severity keeps the baseline entries
+	if err := os.WriteFile(".loglint.yml", []byte("severity:\n  lowercase: warning\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b, err := baseline.Load("baseline.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	findings, err := analyze([]*analysis.Analyzer{loglint.LowercaseAnalyzer}, true, []string{"./..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+	for i := range findings {
+		findings[i].File = relative(wd, findings[i].File)
+	}
+	if added, _ := b.Filter(findings); len(added) != 0 {
+		t.Errorf("findings with a changed severity are new: %+v", added)
+	}
+
+	// the baseline matches when loglint runs from another directory
+	sub := filepath.Join(wd, "cmd")
+	if err := os.Mkdir(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+	if got := run([]string{"-baseline", "../baseline.json", "../..."}); got != 0 {
+		t.Errorf("exit status from a subdirectory = %d, want 0", got)
+	}
+}
@@ -3,163 +3,350 @@ package loglint
 import (
 	"flag"
 	"go/ast"
-	"go/token"
 	"go/types"
+	"path/filepath"
+	"reflect"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/ssa"
 )
 
-var slogMethods = map[string]int{
-	"Debug": 0, "Info": 0, "Warn": 0, "Error": 0,
-	"DebugContext": 1, "InfoContext": 1, "WarnContext": 1, "ErrorContext": 1,
-	"Log": 2, "LogAttrs": 2,
+// CallKind describes how a detected call relates to logging.
+type CallKind int
+
+const (
+	// KindMessage is a call that writes a log entry with a message.
+	KindMessage CallKind = iota
+	// KindSend is a zerolog event finished with Send, without a message.
+	KindSend
+	// KindFields is a call that attaches fields to a logger, such as logrus WithField,
+	// slog.With or zap.Logger.With.
+	KindFields
+	// KindAttr is a call that builds a structured attribute, such as slog.String or zap.Any.
+	KindAttr
+)
+
+// LogCall is a logger call found by the Detector.
+type LogCall struct {
+	Kind CallKind
+	Call *ast.CallExpr
+	Func *types.Func
+	// Message is the message argument of a KindMessage call.
+	Message ast.Expr
+	// Format reports whether Message is a printf-style format for the arguments after it.
+	Format bool
+	// Attrs are the structured keys and values passed to the call.
+	Attrs []Attr
+	// KeyValues are the arguments read as alternating keys and values.
+	KeyValues []ast.Expr
+}
+
+// Result is the result of the Detector analyzer.
+type Result struct {
+	Config Config
+	Calls  []*LogCall
+
+	directives []*directive
+	// configErr is set when the config is invalid; nothing is detected then.
+	configErr *configError
+	// ssaFuncs are the SSA functions of the package, built once by the
+	// first sensitive_flow check.
+	ssaOnce  sync.Once
+	ssaFuncs []*ssa.Function
+	// files holds the configuration of the files that are excluded, tests
+	// or matched by overrides.
+	files map[string]fileConfig
 }
 
-var zapMethods = map[string]bool{
-	"Debug": true, "Info": true, "Warn": true, "Error": true,
-	"DPanic": true, "Panic": true, "Fatal": true,
-	"Debugf": true, "Infof": true, "Warnf": true, "Errorf": true,
-	"DPanicf": true, "Panicf": true, "Fatalf": true,
-	"Debugw": true, "Infow": true, "Warnw": true, "Errorw": true,
-	"DPanicw": true, "Panicw": true, "Fatalw": true,
-	"Debugln": true, "Infoln": true, "Warnln": true, "Errorln": true,
-	"DPanicln": true, "Panicln": true, "Fatalln": true,
+// Detector finds logger calls in a package. It is shared by the rule analyzers.
+var Detector = &analysis.Analyzer{
+	Name:       "logcalls",
+	Doc:        "detects calls to loggers and logger wrappers",
+	Run:        detect,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes:  []analysis.Fact{new(wrapperFact)},
+	ResultType: reflect.TypeOf((*Result)(nil)),
 }
 
+// checksAnalyzer runs the rules enabled in the configuration once per package.
+// The other analyzers report from its result, so no rule runs twice and
+// the directives used by any rule are known to DirectivesAnalyzer.
+var checksAnalyzer = &analysis.Analyzer{
+	Name:       "logchecks",
+	Doc:        "runs the loglint rules enabled in the configuration",
+	Run:        runChecks,
+	Requires:   []*analysis.Analyzer{Detector},
+	ResultType: reflect.TypeOf((*checks)(nil)),
+}
+
+// Analyzer runs all rules enabled in the configuration and checks loglint directives.
 var Analyzer = &analysis.Analyzer{
 	Name:     "loglint",
 	Doc:      "checks log messages for style and security issues",
-	Run:      run,
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      runAll,
+	Requires: []*analysis.Analyzer{Detector, checksAnalyzer},
+}
+
+// DirectivesAnalyzer reports malformed, reason-less and unused loglint directives.
+var DirectivesAnalyzer = &analysis.Analyzer{
+	Name:     "directives",
+	Doc:      "checks //loglint:ignore and //loglint:file-ignore directives",
+	Run:      runDirectives,
+	Requires: []*analysis.Analyzer{Detector, checksAnalyzer},
+}
+
+var (
+	LowercaseAnalyzer       = newRuleAnalyzer(lowercaseRule)
+	EnglishOnlyAnalyzer     = newRuleAnalyzer(englishOnlyRule)
+	NoSpecialAnalyzer       = newRuleAnalyzer(noSpecialRule)
+	SensitiveDataAnalyzer   = newRuleAnalyzer(sensitiveDataRule)
+	SensitiveFlowAnalyzer   = newRuleAnalyzer(sensitiveFlowRule)
+	RedactionAnalyzer       = newRuleAnalyzer(redactionRule)
+	PrintfAnalyzer          = newRuleAnalyzer(printfRule)
+	FormatVerbsAnalyzer     = newRuleAnalyzer(formatVerbsRule)
+	ConstantMessageAnalyzer = newRuleAnalyzer(constantMessageRule)
+	KeyValueAnalyzer        = newRuleAnalyzer(keyValueRule)
+	KeyStyleAnalyzer        = newRuleAnalyzer(keyStyleRule)
+	DuplicateKeysAnalyzer   = newRuleAnalyzer(duplicateKeysRule)
+	KeyRegistryAnalyzer     = newRuleAnalyzer(keyRegistryRule)
+	ZerologSendAnalyzer     = newRuleAnalyzer(zerologSendRule)
+)
+
+// Analyzers contains one analyzer per rule, so rules can be enabled individually.
+var Analyzers = []*analysis.Analyzer{
+	LowercaseAnalyzer,
+	EnglishOnlyAnalyzer,
+	NoSpecialAnalyzer,
+	SensitiveDataAnalyzer,
+	SensitiveFlowAnalyzer,
+	RedactionAnalyzer,
+	PrintfAnalyzer,
+	FormatVerbsAnalyzer,
+	ConstantMessageAnalyzer,
+	KeyValueAnalyzer,
+	KeyStyleAnalyzer,
+	DuplicateKeysAnalyzer,
+	KeyRegistryAnalyzer,
+	ZerologSendAnalyzer,
+	DirectivesAnalyzer,
 }
 
 var configPath string
 
 func init() {
-	Analyzer.Flags = flag.FlagSet{}
-	Analyzer.Flags.StringVar(&configPath, "config", "", "path to .loglint.yml config file")
+	Detector.Flags = flag.FlagSet{}
+	Detector.Flags.StringVar(&configPath, "config", "", "path to .loglint.yml config file (default: discovered from the package directory)")
+}
+
+func newRuleAnalyzer(r *rule) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name: r.name,
+		Doc:  r.doc,
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			c, err := checksOf(pass)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range c.diagnostics {
+				if Rule(d) == r.name {
+					pass.Report(d)
+				}
+			}
+			return nil, nil
+		},
+		Requires: []*analysis.Analyzer{checksAnalyzer},
+	}
+}
+
+func runAll(pass *analysis.Pass) (interface{}, error) {
+	c, err := checksOf(pass)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range c.diagnostics {
+		pass.Report(d)
+	}
+	checkDirectives(pass, pass.ResultOf[Detector].(*Result), c.suppressor)
+	return nil, nil
 }
 
-func run(pass *analysis.Pass) (interface{}, error) {
-	cfg, err := loadConfig(configPath)
+func runDirectives(pass *analysis.Pass) (interface{}, error) {
+	c, err := checksOf(pass)
 	if err != nil {
 		return nil, err
 	}
+	checkDirectives(pass, pass.ResultOf[Detector].(*Result), c.suppressor)
+	return nil, nil
+}
 
-	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+// checks holds the findings of the rules for a package.
+type checks struct {
+	// diagnostics are the findings not ignored by a directive,
+	// categorized by rule name and severity.
+	diagnostics []analysis.Diagnostic
+	// suppressor records the directives used to ignore findings.
+	suppressor *suppressor
+	configErr  *configError
+}
 
-	nodeFilter := []ast.Node{
-		(*ast.CallExpr)(nil),
+// checksOf returns the checks of the package. An invalid config is returned
+// as an error by the first analyzer of the package to ask and leaves nothing
+// to report.
+func checksOf(pass *analysis.Pass) (*checks, error) {
+	c := pass.ResultOf[checksAnalyzer].(*checks)
+	if c.configErr != nil {
+		return c, c.configErr.report(pass.Pkg.Path())
 	}
+	return c, nil
+}
 
-	insp.Preorder(nodeFilter, func(n ast.Node) {
-		call := n.(*ast.CallExpr)
+// runChecks runs the enabled rules on the detected calls, skipping diagnostics
+// ignored by directives. Rules are enabled per file, after applying overrides.
+func runChecks(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[Detector].(*Result)
+	c := &checks{suppressor: newSuppressor(pass.Fset, result.directives), configErr: result.configErr}
+	if result.configErr != nil {
+		return c, nil
+	}
 
-		selector, ok := call.Fun.(*ast.SelectorExpr)
-		if !ok {
-			return
+	for _, r := range rules {
+		// files with the same overrides share a context and its cached analyses
+		ctxs := make(map[string]*ruleContext)
+		for _, call := range result.Calls {
+			fc := result.configFor(pass, call.Call.Pos())
+			if !r.enabled(fc.cfg) {
+				continue
+			}
+			ctx, ok := ctxs[fc.key]
+			if !ok {
+				ctx = &ruleContext{pass: pass, result: result, cfg: fc.cfg, rule: r, checks: c}
+				ctxs[fc.key] = ctx
+			}
+			r.check(ctx, call)
 		}
+	}
 
-		obj := pass.TypesInfo.Uses[selector.Sel]
-		if obj == nil {
-			return
-		}
+	return c, nil
+}
 
-		fn, ok := obj.(*types.Func)
-		if !ok {
-			return
-		}
+func detect(pass *analysis.Pass) (interface{}, error) {
+	cfg, err := packageConfig(pass)
+	if err != nil {
+		// failing here would fail every package depending on this one for
+		// its facts; the rule analyzers report the error instead
+		return &Result{configErr: err}, nil
+	}
 
-		pkg := fn.Pkg()
-		if pkg == nil {
-			return
-		}
+	exportWrapperFacts(pass, cfg.loggerSet)
 
-		pkgPath := pkg.Path()
-		methodName := fn.Name()
-		var msgIndex int
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-		switch pkgPath {
-		case "log/slog":
-			idx, found := slogMethods[methodName]
-			if !found {
-				return
-			}
-			msgIndex = idx
-		case "go.uber.org/zap":
-			if !zapMethods[methodName] {
-				return
-			}
-			msgIndex = 0
-		default:
-			return
-		}
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
 
-		if msgIndex >= len(call.Args) {
+	result := &Result{Config: cfg, files: fileConfigs(pass, cfg)}
+	for _, d := range collectDirectives(pass) {
+		if !result.configFor(pass, d.pos).excluded {
+			result.directives = append(result.directives, d)
+		}
+	}
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if result.configFor(pass, call.Pos()).excluded {
 			return
 		}
 
-		msgArg := call.Args[msgIndex]
-		lits := collectLits(msgArg)
-		values := litValues(lits)
-
-		if cfg.isLowercaseEnabled() && len(values) > 0 && values[0] != "" {
-			if isUppercaseStart(values[0]) {
-				d := analysis.Diagnostic{
-					Pos:     msgArg.Pos(),
-					Message: "log message should start with a lowercase letter",
-				}
-				if lit, ok := msgArg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
-					fixed := toLowercaseStart(values[0])
-					if cfg.isNoSpecialEnabled() && hasSpecialChars(fixed) {
-						fixed = stripSpecialChars(fixed)
-					}
-					d.SuggestedFixes = suggestedFix("fix log message", lit, fixed)
-				}
-				pass.Report(d)
-			}
+		fn := calleeFunc(pass.TypesInfo, call)
+		if fn == nil || fn.Pkg() == nil {
+			return
 		}
 
-		if cfg.isEnglishOnlyEnabled() {
-			for _, val := range values {
-				if hasNonEnglish(val) {
-					pass.Reportf(msgArg.Pos(), "log message should be in English only")
-					break
-				}
-			}
+		if lc := detectCall(pass, cfg.loggerSet, call, fn); lc != nil {
+			result.Calls = append(result.Calls, lc)
 		}
+	})
 
-		if cfg.isNoSpecialEnabled() {
-			for i, val := range values {
-				if !hasSpecialChars(val) {
-					continue
-				}
-
-				d := analysis.Diagnostic{
-					Pos:     msgArg.Pos(),
-					Message: "log message should not contain special characters or emoji",
-				}
+	return result, nil
+}
 
-				needsFix := true
-				if i == 0 && cfg.isLowercaseEnabled() && len(values) > 0 && isUppercaseStart(values[0]) {
-					needsFix = false
-				}
+// packageConfig returns the config given by the -config flag or, without it,
+// the configs discovered from the package directory.
+func packageConfig(pass *analysis.Pass) (Config, *configError) {
+	if configPath != "" || len(pass.Files) == 0 {
+		return cachedConfig(configPath)
+	}
+	// the position of the package clause follows line directives,
+	// so files generated by cgo resolve to the source directory
+	dir := filepath.Dir(pass.Fset.Position(pass.Files[0].Package).Filename)
+	return cachedConfig(discoverConfigs(dir)...)
+}
 
-				if needsFix && i < len(lits) {
-					d.SuggestedFixes = suggestedFix("remove special characters", lits[i], stripSpecialChars(val))
-				}
-				pass.Report(d)
-				break
-			}
+// detectCall returns the LogCall for call, or nil if fn is not a logger.
+func detectCall(pass *analysis.Pass, loggers loggerSet, call *ast.CallExpr, fn *types.Func) *LogCall {
+	info := pass.TypesInfo
+	switch {
+	case fn.Pkg().Path() == logrusPkg && (fn.Name() == "WithField" || fn.Name() == "WithFields"):
+		return &LogCall{Kind: KindFields, Call: call, Func: fn, Attrs: logrusFields(call)}
+	case isKeyValueWith(fn):
+		lc := &LogCall{Kind: KindFields, Call: call, Func: fn}
+		if !call.Ellipsis.IsValid() {
+			lc.KeyValues = call.Args
+			lc.Attrs = keyValuePairs(info, lc.KeyValues)
 		}
-
-		if cfg.isSensitiveDataEnabled() {
-			checkSensitiveData(pass, msgArg, cfg.sensitiveKeywords())
+		return lc
+	case isFieldWith(fn):
+		// the fields are reported as attribute constructor calls of their own
+		return &LogCall{Kind: KindFields, Call: call, Func: fn}
+	case isAttrConstructor(fn) && len(call.Args) > 0:
+		lc := &LogCall{Kind: KindAttr, Call: call, Func: fn, Attrs: constructorAttrs(info, call, fn)}
+		if isSlogGroup(fn) && !call.Ellipsis.IsValid() {
+			lc.KeyValues = call.Args[1:]
 		}
-	})
+		return lc
+	case isZerologEventMethod(fn) && fn.Name() == "Send":
+		return &LogCall{Kind: KindSend, Call: call, Func: fn}
+	}
 
-	return nil, nil
+	args, ok := loggerArgsOf(pass, loggers, fn)
+	if !ok || args.msgIndex >= len(call.Args) {
+		return nil
+	}
+	lc := &LogCall{Kind: KindMessage, Call: call, Func: fn, Message: call.Args[args.msgIndex], Format: args.format}
+	if args.keyValues && !call.Ellipsis.IsValid() {
+		lc.KeyValues = call.Args[args.msgIndex+1:]
+		lc.Attrs = keyValuePairs(info, lc.KeyValues)
+	}
+	return lc
 }
 
+// calleeFunc returns the function or method called by call, or nil for other calls.
+func calleeFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	var id *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	default:
+		return nil
+	}
+	fn, _ := info.Uses[id].(*types.Func)
+	return fn
+}
+
+// loggerArgsOf returns the arguments of fn if fn is a known logger or a wrapper around one.
+func loggerArgsOf(pass *analysis.Pass, loggers loggerSet, fn *types.Func) (loggerArgs, bool) {
+	if args, ok := loggers.match(fn); ok {
+		return args, true
+	}
+
+	var fact wrapperFact
+	if pass.ImportObjectFact(fn.Origin(), &fact) {
+		return loggerArgs{msgIndex: fact.MsgIndex, format: fact.Format}, true
+	}
+	return loggerArgs{}, false
+}
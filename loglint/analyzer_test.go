@@ -1,18 +1,159 @@
 package loglint_test
 
 import (
-	loglint "github.com/RomanKovalev007/log_linter/loglint"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	loglint "github.com/RomanKovalev007/log_linter/loglint"
+
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
 )
 
 func TestAnalyzer(t *testing.T) {
 	testdata := analysistest.TestData()
-	analysistest.Run(t, testdata, loglint.Analyzer, "testcases")
+	analysistest.Run(t, testdata, loglint.Analyzer, "testcases", "stdlog", "logrustest", "zerologtest", "wrapperlocal", "wrapperuse", "directives", "attrs", "redaction", "printf", "formatverbs", "constants", "acronyms", "keyvalue", "duplicatekeys")
 }
 
 func TestAnalyzerFixes(t *testing.T) {
 	testdata := analysistest.TestData()
-	analysistest.RunWithSuggestedFixes(t, testdata, loglint.Analyzer, "testcases")
+	analysistest.RunWithSuggestedFixes(t, testdata, loglint.Analyzer, "testcases", "stdlog", "logrustest", "zerologtest", "wrapperlocal", "wrapperuse", "printf", "formatverbs", "constants", "acronyms", "keyvalue")
+}
+
+// TestRuleAnalyzers runs each analyzer on its fixture, whose .loglint.yml
+// enables the rule, so opt-in rules are checked too.
+func TestRuleAnalyzers(t *testing.T) {
+	testdata := analysistest.TestData()
+	for _, a := range loglint.Analyzers {
+		t.Run(a.Name, func(t *testing.T) {
+			analysistest.Run(t, testdata, a, "ruleanalyzers/"+a.Name)
+		})
+	}
+}
+
+func TestDetectorWrapperFacts(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, loglint.Detector, "wrapper")
+}
+
+func TestAnalyzerZerologSend(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "zerologsend", ".loglint.yml"))
+	analysistest.Run(t, testdata, loglint.Analyzer, "zerologsend")
+}
+
+func TestAnalyzerCustomLoggers(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "customloggers", ".loglint.yml"))
+	analysistest.Run(t, testdata, loglint.Analyzer, "customloggers")
+}
+
+func TestAnalyzerSensitiveFlow(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "sensitiveflow", ".loglint.yml"))
+	analysistest.Run(t, testdata, loglint.Analyzer, "sensitiveflow")
+}
+
+func TestAnalyzerKeyStyle(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "keystyle", ".loglint.yml"))
+	analysistest.RunWithSuggestedFixes(t, testdata, loglint.Analyzer, "keystyle")
+}
+
+func TestAnalyzerConstantMessage(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "constantmessage", ".loglint.yml"))
+	analysistest.RunWithSuggestedFixes(t, testdata, loglint.Analyzer, "constantmessage")
+}
+
+func TestAnalyzerSpecialChars(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "specialchars", ".loglint.yml"))
+	analysistest.RunWithSuggestedFixes(t, testdata, loglint.Analyzer, "specialchars")
+}
+
+func TestAnalyzerKeyRegistry(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "keyregistry", ".loglint.yml"))
+	analysistest.Run(t, testdata, loglint.Analyzer, "keyregistry")
+}
+
+func TestAnalyzerOverrides(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "overrides", ".loglint.yml"))
+	analysistest.Run(t, testdata, loglint.Analyzer, "overrides", "overrides/legacy")
+}
+
+func TestAnalyzerExclude(t *testing.T) {
+	testdata := analysistest.TestData()
+	setConfig(t, filepath.Join(testdata, "src", "exclude", ".loglint.yml"))
+	analysistest.Run(t, testdata, loglint.Analyzer, "exclude", "exclude/mocks")
+}
+
+// TestAnalyzerConfigDiscovery runs without -config, so each package uses the
+// configs found walking up from its directory.
+func TestAnalyzerConfigDiscovery(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, loglint.Analyzer, "nested", "nested/inner")
+}
+
+// TestAnalyzerConfigErrorPerPackage checks that every package using a broken
+// config reports the error once, so it is not lost when the first package is.
+func TestAnalyzerConfigErrorPerPackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":       "module example.com/app\n\ngo 1.24\n",
+		".loglint.yml": "rules: [\n",
+		"a/a.go":       "package a\n\nimport \"log/slog\"\n\nfunc A() { slog.Info(\"Started\") }\n",
+		"b/b.go":       "package b\n\nimport \"log/slog\"\n\nfunc B() { slog.Info(\"Started\") }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzers := []*analysis.Analyzer{loglint.LowercaseAnalyzer, loglint.NoSpecialAnalyzer}
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make(map[string]int)
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			if !strings.Contains(act.Err.Error(), "invalid config") {
+				t.Errorf("%s: unexpected error %v", act, act.Err)
+			}
+			errs[act.Package.PkgPath]++
+		}
+	}
+	for _, pkg := range []string{"example.com/app/a", "example.com/app/b"} {
+		if errs[pkg] != 1 {
+			t.Errorf("%s reported the config error %d times, want 1", pkg, errs[pkg])
+		}
+	}
+}
+
+// setConfig points the analyzer at a config file for the duration of the test.
+func setConfig(t *testing.T, path string) {
+	t.Helper()
+	if err := loglint.Detector.Flags.Set("config", path); err != nil {
+		t.Fatalf("failed to set config flag: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = loglint.Detector.Flags.Set("config", "")
+	})
 }
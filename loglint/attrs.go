@@ -0,0 +1,132 @@
+package loglint
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+const zapcorePkg = "go.uber.org/zap/zapcore"
+
+// Attr is a structured logging key and its value.
+type Attr struct {
+	Key ast.Expr
+	// Value is nil when the key has no value of its own, such as the key of slog.Group.
+	Value ast.Expr
+}
+
+// keyValuePairs pairs arguments read as alternating keys and values.
+// An argument of type slog.Attr or zap.Field stands on its own, as it does at run time.
+// A trailing key without a value gets a nil Value.
+func keyValuePairs(info *types.Info, args []ast.Expr) []Attr {
+	var attrs []Attr
+	for i := 0; i < len(args); i++ {
+		if isAttrType(info.TypeOf(args[i])) {
+			continue
+		}
+		a := Attr{Key: args[i]}
+		if i+1 < len(args) {
+			a.Value = args[i+1]
+			i++
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs
+}
+
+// isAttrType reports whether t is slog.Attr or zap.Field.
+func isAttrType(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	switch named.Obj().Pkg().Path() {
+	case slogPkg:
+		return named.Obj().Name() == "Attr"
+	case zapPkg, zapcorePkg:
+		return named.Obj().Name() == "Field"
+	}
+	return false
+}
+
+// isAttrConstructor reports whether fn builds a slog.Attr or zap.Field
+// from a string key, such as slog.String, slog.Group or zap.Any.
+func isAttrConstructor(fn *types.Func) bool {
+	if path := fn.Pkg().Path(); path != slogPkg && path != zapPkg {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() != nil || sig.Params().Len() == 0 || sig.Results().Len() != 1 {
+		return false
+	}
+	return isString(sig.Params().At(0).Type()) && isAttrType(sig.Results().At(0).Type())
+}
+
+// isKeyValueWith reports whether fn returns a logger with alternating keys and values
+// attached, such as slog.With or zap.SugaredLogger.With.
+func isKeyValueWith(fn *types.Func) bool {
+	if fn.Name() != "With" {
+		return false
+	}
+	switch fn.Pkg().Path() {
+	case slogPkg:
+		return true
+	case zapPkg:
+		return receiverName(fn) == "SugaredLogger"
+	}
+	return false
+}
+
+// isFieldWith reports whether fn returns a logger with structured fields
+// attached, such as zap.Logger.With.
+func isFieldWith(fn *types.Func) bool {
+	return fn.Name() == "With" && fn.Pkg().Path() == zapPkg && receiverName(fn) == "Logger"
+}
+
+// constructorAttrs returns the attributes built by a call to an attribute constructor.
+// slog.Group contributes its own key and the pairs of its arguments.
+func constructorAttrs(info *types.Info, call *ast.CallExpr, fn *types.Func) []Attr {
+	if isSlogGroup(fn) {
+		attrs := []Attr{{Key: call.Args[0]}}
+		if !call.Ellipsis.IsValid() {
+			attrs = append(attrs, keyValuePairs(info, call.Args[1:])...)
+		}
+		return attrs
+	}
+
+	a := Attr{Key: call.Args[0]}
+	if len(call.Args) > 1 {
+		a.Value = call.Args[1]
+	}
+	return []Attr{a}
+}
+
+func isSlogGroup(fn *types.Func) bool {
+	return fn.Pkg().Path() == slogPkg && fn.Name() == "Group"
+}
+
+// logrusFields returns the fields of a logrus WithField or WithFields call.
+// Only WithFields calls with a composite literal argument are inspected.
+func logrusFields(call *ast.CallExpr) []Attr {
+	switch len(call.Args) {
+	case 2:
+		return []Attr{{Key: call.Args[0], Value: call.Args[1]}}
+	case 1:
+		fields, ok := call.Args[0].(*ast.CompositeLit)
+		if !ok {
+			return nil
+		}
+		var attrs []Attr
+		for _, elt := range fields.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				attrs = append(attrs, Attr{Key: kv.Key, Value: kv.Value})
+			}
+		}
+		return attrs
+	}
+	return nil
+}
+
+func isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
@@ -1,7 +1,13 @@
 package loglint
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -10,14 +16,49 @@ import (
 type Config struct {
 	Rules    RulesConfig `yaml:"rules"`
 	Keywords []string    `yaml:"sensitive_keywords"`
+	// LowercaseExceptions are words a message may start with despite the
+	// lowercase rule, in addition to defaultLowercaseExceptions.
+	LowercaseExceptions []string       `yaml:"lowercase_exceptions"`
+	Loggers             []LoggerConfig `yaml:"loggers"`
+	KeyStyle            KeyStyleConfig `yaml:"key_style"`
+	// SpecialChars configures the characters allowed by the no_special_chars rule.
+	SpecialChars SpecialCharsConfig `yaml:"special_chars"`
+	// KeyRegistry is the path to a YAML list of RegistryKey entries,
+	// relative to the config file.
+	KeyRegistry string `yaml:"key_registry"`
+
+	// Exclude lists the files that are not checked.
+	Exclude ExcludeConfig `yaml:"exclude"`
+
+	// Severity maps rule keys, as in Rules, to error, warning or info.
+	Severity  map[string]string `yaml:"severity"`
+	Overrides []Override        `yaml:"overrides"`
+
+	// registry maps the registered keys to their expected types.
+	registry map[string]string
+	// loggerSet, style and chars are compiled from Loggers, KeyStyle and
+	// SpecialChars when the config is loaded.
+	loggerSet loggerSet
+	style     *keyStyle
+	chars     specialChars
 }
 
 // RulesConfig controls which rules are enabled.
 type RulesConfig struct {
-	Lowercase    *bool `yaml:"lowercase"`
-	EnglishOnly  *bool `yaml:"english_only"`
-	NoSpecial    *bool `yaml:"no_special_chars"`
-	SensitiveData *bool `yaml:"sensitive_data"`
+	Lowercase       *bool `yaml:"lowercase"`
+	EnglishOnly     *bool `yaml:"english_only"`
+	NoSpecial       *bool `yaml:"no_special_chars"`
+	SensitiveData   *bool `yaml:"sensitive_data"`
+	SensitiveFlow   *bool `yaml:"sensitive_flow"`
+	Redaction       *bool `yaml:"redaction"`
+	Printf          *bool `yaml:"printf"`
+	FormatVerbs     *bool `yaml:"format_verbs"`
+	ConstantMessage *bool `yaml:"constant_message"`
+	KeyValue        *bool `yaml:"key_value"`
+	KeyStyle        *bool `yaml:"key_style"`
+	DuplicateKeys   *bool `yaml:"duplicate_keys"`
+	KeyRegistry     *bool `yaml:"key_registry"`
+	ZerologSend     *bool `yaml:"zerolog_send"`
 }
 
 func defaultConfig() Config {
@@ -28,6 +69,11 @@ func defaultConfig() Config {
 			EnglishOnly:   &t,
 			NoSpecial:     &t,
 			SensitiveData: &t,
+			Redaction:     &t,
+			Printf:        &t,
+			FormatVerbs:   &t,
+			KeyValue:      &t,
+			DuplicateKeys: &t,
 		},
 		Keywords: defaultSensitiveKeywords,
 	}
@@ -43,6 +89,32 @@ var defaultSensitiveKeywords = []string{
 	"session_id",
 }
 
+// defaultLowercaseExceptions are common initialisms and proper nouns
+// that keep their capitalization at the start of a message.
+var defaultLowercaseExceptions = []string{
+	"API", "AWS", "CPU", "CSV", "DB", "DNS", "EOF", "GCP", "GPU", "GRPC", "HTML", "HTTP", "HTTPS",
+	"I", "ID", "IO", "IP", "JSON", "JWT", "LDAP", "OK", "OS", "RPC", "S3", "SMTP", "SQL", "SSH",
+	"SSL", "TCP", "TLS", "TTL", "UDP", "UI", "URI", "URL", "UTC", "UUID", "XML", "YAML",
+	"Docker", "GitHub", "GraphQL", "Kafka", "Kubernetes", "MongoDB", "MySQL", "OAuth",
+	"PostgreSQL", "Postgres", "RabbitMQ", "Redis",
+}
+
+// isLowercaseException reports whether a message may start with word.
+// The plural of an exception, such as IDs, is an exception as well.
+func (c Config) isLowercaseException(word string) bool {
+	for _, w := range []string{word, strings.TrimSuffix(word, "s")} {
+		if slices.Contains(defaultLowercaseExceptions, w) || slices.Contains(c.LowercaseExceptions, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsLowercase reports whether the lowercase rule reports the message.
+func (c Config) needsLowercase(msg string) bool {
+	return isUppercaseStart(msg) && !c.isLowercaseException(firstWord(msg))
+}
+
 func (c Config) isLowercaseEnabled() bool {
 	return c.Rules.Lowercase == nil || *c.Rules.Lowercase
 }
@@ -59,6 +131,65 @@ func (c Config) isSensitiveDataEnabled() bool {
 	return c.Rules.SensitiveData == nil || *c.Rules.SensitiveData
 }
 
+func (c Config) isRedactionEnabled() bool {
+	return c.Rules.Redaction == nil || *c.Rules.Redaction
+}
+
+func (c Config) isPrintfEnabled() bool {
+	return c.Rules.Printf == nil || *c.Rules.Printf
+}
+
+func (c Config) isFormatVerbsEnabled() bool {
+	return c.Rules.FormatVerbs == nil || *c.Rules.FormatVerbs
+}
+
+func (c Config) isKeyValueEnabled() bool {
+	return c.Rules.KeyValue == nil || *c.Rules.KeyValue
+}
+
+func (c Config) isDuplicateKeysEnabled() bool {
+	return c.Rules.DuplicateKeys == nil || *c.Rules.DuplicateKeys
+}
+
+// isKeyRegistryEnabled defaults to whether a key registry is configured.
+func (c Config) isKeyRegistryEnabled() bool {
+	if c.Rules.KeyRegistry != nil {
+		return *c.Rules.KeyRegistry
+	}
+	return c.KeyRegistry != ""
+}
+
+// isKeyStyleEnabled is opt-in: projects choose their own key convention.
+func (c Config) isKeyStyleEnabled() bool {
+	return c.Rules.KeyStyle != nil && *c.Rules.KeyStyle
+}
+
+// isConstantMessageEnabled is opt-in: most existing code builds some messages
+// at run time, and moving the variables to attributes is a project decision.
+func (c Config) isConstantMessageEnabled() bool {
+	return c.Rules.ConstantMessage != nil && *c.Rules.ConstantMessage
+}
+
+// isSensitiveFlowEnabled is opt-in: the rule builds the SSA form of each package
+// it checks, which the other rules do not need, and reports data flow that the
+// sensitive_data rule does not see.
+func (c Config) isSensitiveFlowEnabled() bool {
+	return c.Rules.SensitiveFlow != nil && *c.Rules.SensitiveFlow
+}
+
+// isZerologSendEnabled is opt-in: the rule is disabled unless explicitly enabled.
+func (c Config) isZerologSendEnabled() bool {
+	return c.Rules.ZerologSend != nil && *c.Rules.ZerologSend
+}
+
+// severity returns the severity level of the rule, error by default.
+func (c Config) severity(r *rule) string {
+	if level, ok := c.Severity[r.option]; ok {
+		return level
+	}
+	return "error"
+}
+
 func (c Config) sensitiveKeywords() []string {
 	if len(c.Keywords) > 0 {
 		return c.Keywords
@@ -66,9 +197,16 @@ func (c Config) sensitiveKeywords() []string {
 	return defaultSensitiveKeywords
 }
 
+// loggers returns the custom logger definitions followed by the built-in ones.
+func (c Config) loggers() []LoggerConfig {
+	return append(slices.Clone(c.Loggers), defaultLoggers...)
+}
+
 func loadConfig(path string) (Config, error) {
 	if path == "" {
-		return defaultConfig(), nil
+		cfg := defaultConfig()
+		err := cfg.compile()
+		return cfg, err
 	}
 
 	data, err := os.ReadFile(path)
@@ -81,5 +219,187 @@ func loadConfig(path string) (Config, error) {
 		return Config{}, err
 	}
 
+	if err := cfg.compile(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Exclude.validate(); err != nil {
+		return Config{}, err
+	}
+	if err := validateSeverity(cfg.Severity); err != nil {
+		return Config{}, err
+	}
+	for i, o := range cfg.Overrides {
+		if err := o.validate(); err != nil {
+			return Config{}, fmt.Errorf("overrides[%d]: %w", i, err)
+		}
+	}
+	cfg.Exclude.dir = filepath.Dir(path)
+	for i := range cfg.Overrides {
+		cfg.Overrides[i].dir = filepath.Dir(path)
+	}
+
+	if cfg.KeyRegistry != "" {
+		keys, err := loadRegistry(path, cfg.KeyRegistry)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.registry = make(map[string]string, len(keys))
+		for _, k := range keys {
+			cfg.registry[k.Key] = k.Type
+		}
+	}
+
 	return cfg, nil
 }
+
+// compile validates the logger, key style and special character settings and
+// builds their matchers, so they are built once per config rather than per package.
+// Sensitive keywords are lowercased, as messages are matched case-insensitively.
+func (c *Config) compile() error {
+	var err error
+	if c.loggerSet, err = newLoggerSet(c.loggers()); err != nil {
+		return err
+	}
+	if c.style, err = newKeyStyle(c.KeyStyle); err != nil {
+		return err
+	}
+	if c.chars, err = newSpecialChars(c.SpecialChars); err != nil {
+		return err
+	}
+	c.Keywords = lowerAll(c.Keywords)
+	for i := range c.Overrides {
+		c.Overrides[i].Keywords = lowerAll(c.Overrides[i].Keywords)
+	}
+	return nil
+}
+
+func lowerAll(words []string) []string {
+	if words == nil {
+		return nil
+	}
+	lower := make([]string, len(words))
+	for i, w := range words {
+		lower[i] = strings.ToLower(w)
+	}
+	return lower
+}
+
+// configError is an error loading a config. It is shared by all packages
+// using the config and reported once by each of them.
+type configError struct {
+	path string
+	err  error
+
+	mu       sync.Mutex
+	reported map[string]bool
+}
+
+func (e *configError) Error() string {
+	return fmt.Sprintf("invalid config %s: %v", e.path, e.err)
+}
+
+func (e *configError) Unwrap() error {
+	return e.err
+}
+
+// report returns the error the first time it is called for the package and
+// nil afterwards, so each package using the config reports it once, however
+// many of its analyzers ask.
+func (e *configError) report(pkg string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.reported[pkg] {
+		return nil
+	}
+	if e.reported == nil {
+		e.reported = make(map[string]bool)
+	}
+	e.reported[pkg] = true
+	return e
+}
+
+// configCache holds the configs loaded by the process. files are keyed by
+// absolute path; chains, the configs merged from nested files, by their paths.
+var configCache = struct {
+	sync.Mutex
+	files  map[string]*configEntry
+	chains map[string]*chainEntry
+}{files: make(map[string]*configEntry), chains: make(map[string]*chainEntry)}
+
+type configEntry struct {
+	modTime time.Time
+	cfg     Config
+	err     *configError
+}
+
+type chainEntry struct {
+	files []*configEntry
+	cfg   Config
+	err   *configError
+}
+
+// cachedConfig returns the config merged from the files at paths, outermost
+// first, or the default config if there are none. Each file is loaded only
+// the first time and again when its modification time changes.
+func cachedConfig(paths ...string) (Config, *configError) {
+	if len(paths) == 0 {
+		paths = []string{""}
+	}
+
+	configCache.Lock()
+	defer configCache.Unlock()
+
+	files := make([]*configEntry, len(paths))
+	for i, path := range paths {
+		files[i] = cachedFile(path)
+		if files[i].err != nil {
+			return Config{}, files[i].err
+		}
+	}
+	if len(files) == 1 {
+		return files[0].cfg, nil
+	}
+
+	key := strings.Join(paths, string(filepath.ListSeparator))
+	c, ok := configCache.chains[key]
+	if !ok || !slices.Equal(c.files, files) {
+		c = &chainEntry{files: files, cfg: files[0].cfg}
+		for i, e := range files[1:] {
+			var err error
+			if c.cfg, err = c.cfg.merge(e.cfg); err != nil {
+				c.err = &configError{path: paths[i+1], err: err}
+				break
+			}
+		}
+		configCache.chains[key] = c
+	}
+	return c.cfg, c.err
+}
+
+// cachedFile returns the cache entry of the config file at path, loading the
+// file if it is not cached or has changed. configCache must be locked.
+func cachedFile(path string) *configEntry {
+	abs := path
+	if path != "" {
+		var err error
+		if abs, err = filepath.Abs(path); err != nil {
+			return &configEntry{err: &configError{path: path, err: err}}
+		}
+	}
+
+	var modTime time.Time
+	if info, err := os.Stat(abs); err == nil {
+		modTime = info.ModTime()
+	}
+	e, ok := configCache.files[abs]
+	if !ok || !e.modTime.Equal(modTime) {
+		e = &configEntry{modTime: modTime}
+		cfg, err := loadConfig(abs)
+		if err != nil {
+			e.err = &configError{path: path, err: err}
+		}
+		e.cfg = cfg
+		configCache.files[abs] = e
+	}
+	return e
+}
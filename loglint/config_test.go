@@ -1,9 +1,12 @@
 package loglint
 
 import (
+	"maps"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -21,9 +24,42 @@ func TestDefaultConfig(t *testing.T) {
 	if !cfg.isSensitiveDataEnabled() {
 		t.Error("sensitive_data should be enabled by default")
 	}
+	if !cfg.isRedactionEnabled() {
+		t.Error("redaction should be enabled by default")
+	}
+	if !cfg.isPrintfEnabled() {
+		t.Error("printf should be enabled by default")
+	}
+	if !cfg.isFormatVerbsEnabled() {
+		t.Error("format_verbs should be enabled by default")
+	}
+	if !cfg.isKeyValueEnabled() {
+		t.Error("key_value should be enabled by default")
+	}
+	if !cfg.isDuplicateKeysEnabled() {
+		t.Error("duplicate_keys should be enabled by default")
+	}
+	if cfg.isKeyRegistryEnabled() {
+		t.Error("key_registry should be disabled without a registry")
+	}
 	if len(cfg.sensitiveKeywords()) == 0 {
 		t.Error("sensitive keywords should not be empty by default")
 	}
+	if cfg.isZerologSendEnabled() {
+		t.Error("zerolog_send should be disabled by default")
+	}
+	if cfg.isConstantMessageEnabled() {
+		t.Error("constant_message should be disabled by default")
+	}
+	if cfg.isSensitiveFlowEnabled() {
+		t.Error("sensitive_flow should be disabled by default")
+	}
+	if !cfg.Exclude.excludesGenerated() {
+		t.Error("generated files should be excluded by default")
+	}
+	if !cfg.Exclude.excludesTests() {
+		t.Error("test files should be excluded by default")
+	}
 }
 
 func TestLoadConfigEmpty(t *testing.T) {
@@ -64,6 +100,26 @@ rules:
 	}
 }
 
+func TestLoadConfigEnableOptInRules(t *testing.T) {
+	content := `
+rules:
+  zerolog_send: true
+  sensitive_flow: true
+`
+	path := writeTempFile(t, content)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if !cfg.isZerologSendEnabled() {
+		t.Error("zerolog_send should be enabled")
+	}
+	if !cfg.isSensitiveFlowEnabled() {
+		t.Error("sensitive_flow should be enabled")
+	}
+}
+
 func TestLoadConfigCustomKeywords(t *testing.T) {
 	content := `
 sensitive_keywords:
@@ -86,6 +142,20 @@ sensitive_keywords:
 	}
 }
 
+func TestLoadConfigKeywordsLowercased(t *testing.T) {
+	path := writeTempFile(t, "sensitive_keywords: [SSN]\noverrides:\n  - packages: [app]\n    sensitive_keywords: [PIN]\n")
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if kw := cfg.sensitiveKeywords(); len(kw) != 1 || kw[0] != "ssn" {
+		t.Errorf("sensitive keywords = %v, want [ssn]", kw)
+	}
+	if kw := cfg.Overrides[0].Keywords; len(kw) != 1 || kw[0] != "pin" {
+		t.Errorf("override sensitive keywords = %v, want [pin]", kw)
+	}
+}
+
 func TestLoadConfigPartial(t *testing.T) {
 	content := `
 rules:
@@ -112,6 +182,299 @@ rules:
 	}
 }
 
+func TestLoadConfigLoggers(t *testing.T) {
+	content := `
+loggers:
+  - package: example.com/logging
+    receiver: Logger
+    methods: [Notice]
+    message_index: 1
+`
+	path := writeTempFile(t, content)
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+
+	loggers := cfg.loggers()
+	if len(loggers) != len(defaultLoggers)+1 {
+		t.Fatalf("expected %d loggers, got %d", len(defaultLoggers)+1, len(loggers))
+	}
+	if loggers[0].Package != "example.com/logging" || loggers[0].Receiver != "Logger" || loggers[0].MessageIndex != 1 {
+		t.Errorf("unexpected custom logger: %+v", loggers[0])
+	}
+}
+
+func TestLoadConfigInvalidLoggers(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"missing package", "loggers:\n  - methods: [Info]\n"},
+		{"missing methods", "loggers:\n  - package: example.com/logging\n"},
+		{"invalid regex", "loggers:\n  - package: example.com/logging\n    methods_regex: \"(\"\n"},
+		{"negative index", "loggers:\n  - package: example.com/logging\n    methods: [Info]\n    message_index: -1\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempFile(t, tt.content)
+			if _, err := loadConfig(path); err == nil {
+				t.Error("expected error for invalid logger definition")
+			}
+		})
+	}
+}
+
+func TestLoadConfigInvalidKeyStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown style", "key_style:\n  style: PascalCase\n"},
+		{"invalid pattern", "key_style:\n  pattern: \"(\"\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempFile(t, tt.content)
+			if _, err := loadConfig(path); err == nil {
+				t.Error("expected error for invalid key_style")
+			}
+		})
+	}
+}
+
+func TestLoadConfigSpecialChars(t *testing.T) {
+	path := writeTempFile(t, "special_chars:\n  allowed: [hyphen, inner_dot, \"#\"]\n  allow_emoji: true\n")
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if len(cfg.SpecialChars.Allowed) != 3 || !cfg.SpecialChars.allowsEmoji() {
+		t.Errorf("unexpected special_chars config: %+v", cfg.SpecialChars)
+	}
+
+	path = writeTempFile(t, "special_chars:\n  allowed: [dots]\n")
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error for unknown character class")
+	}
+}
+
+func TestLoadConfigKeyRegistry(t *testing.T) {
+	dir := t.TempDir()
+	registry := "- key: user_id\n  type: string\n- key: duration\n  type: time.Duration\n- key: request\n"
+	if err := os.WriteFile(filepath.Join(dir, "keys.yml"), []byte(registry), 0644); err != nil {
+		t.Fatalf("failed to write registry: %v", err)
+	}
+	path := filepath.Join(dir, ".loglint.yml")
+	if err := os.WriteFile(path, []byte("key_registry: keys.yml\n"), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if !cfg.isKeyRegistryEnabled() {
+		t.Error("key_registry should be enabled when a registry is configured")
+	}
+	want := map[string]string{"user_id": "string", "duration": "time.Duration", "request": ""}
+	if !maps.Equal(cfg.registry, want) {
+		t.Errorf("registry = %v, want %v", cfg.registry, want)
+	}
+}
+
+func TestLoadConfigInvalidKeyRegistry(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry string
+	}{
+		{"missing key", "- type: string\n"},
+		{"duplicate key", "- key: user_id\n- key: user_id\n"},
+		{"not a list", "user_id: string\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "keys.yml"), []byte(tt.registry), 0644); err != nil {
+				t.Fatalf("failed to write registry: %v", err)
+			}
+			path := filepath.Join(dir, ".loglint.yml")
+			if err := os.WriteFile(path, []byte("key_registry: keys.yml\n"), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			if _, err := loadConfig(path); err == nil {
+				t.Error("expected error for invalid key registry")
+			}
+		})
+	}
+
+	path := writeTempFile(t, "key_registry: missing.yml\n")
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected error for missing key registry")
+	}
+}
+
+func TestLoadConfigOverrides(t *testing.T) {
+	content := `
+severity:
+  no_special_chars: warning
+overrides:
+  - packages: [example.com/legacy/...]
+    rules:
+      english_only: false
+    sensitive_keywords: [pin]
+  - files: ["cmd/**"]
+    severity:
+      lowercase: info
+`
+	path := writeTempFile(t, content)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if len(cfg.Overrides) != 2 {
+		t.Fatalf("got %d overrides, want 2", len(cfg.Overrides))
+	}
+
+	legacy := cfg.Overrides[0].apply(cfg)
+	if legacy.isEnglishOnlyEnabled() {
+		t.Error("english_only should be disabled by the override")
+	}
+	if !legacy.isLowercaseEnabled() {
+		t.Error("lowercase should stay enabled when the override does not set it")
+	}
+	if kw := legacy.sensitiveKeywords(); len(kw) != 1 || kw[0] != "pin" {
+		t.Errorf("sensitive keywords = %v, want [pin]", kw)
+	}
+
+	cmd := cfg.Overrides[1].apply(cfg)
+	want := map[string]string{"no_special_chars": "warning", "lowercase": "info"}
+	if !maps.Equal(cmd.Severity, want) {
+		t.Errorf("severity = %v, want %v", cmd.Severity, want)
+	}
+	if len(cfg.Severity) != 1 {
+		t.Errorf("applying an override changed the base severity: %v", cfg.Severity)
+	}
+}
+
+func TestLoadConfigInvalidOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"unknown severity rule", "severity:\n  uppercase: warning\n"},
+		{"unknown severity level", "severity:\n  lowercase: fatal\n"},
+		{"no patterns", "overrides:\n  - rules:\n      lowercase: false\n"},
+		{"invalid glob", "overrides:\n  - files: [\"[\"]\n"},
+		{"invalid override severity", "overrides:\n  - packages: [app]\n    severity:\n      lowercase: fatal\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempFile(t, tt.content)
+			if _, err := loadConfig(path); err == nil {
+				t.Error("expected error for invalid overrides")
+			}
+		})
+	}
+}
+
+func TestLoadConfigExclude(t *testing.T) {
+	content := `
+exclude:
+  generated: false
+  test_rules: [sensitive_data, sensitive_flow]
+  paths: ["mocks/**"]
+`
+	path := writeTempFile(t, content)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig: %v", err)
+	}
+	if cfg.Exclude.excludesGenerated() {
+		t.Error("generated files should not be excluded")
+	}
+	if !cfg.Exclude.excludesTests() {
+		t.Error("test files should stay excluded when tests is not set")
+	}
+
+	tests := defaultConfig()
+	tests.Rules = tests.Rules.merge(cfg.Exclude.testRules())
+	if !tests.isSensitiveDataEnabled() {
+		t.Error("sensitive_data should check test files")
+	}
+	if tests.isSensitiveFlowEnabled() {
+		t.Error("sensitive_flow should stay disabled in test files when disabled globally")
+	}
+	if tests.isLowercaseEnabled() {
+		t.Error("lowercase should not check test files")
+	}
+
+	for _, content := range []string{
+		"exclude:\n  test_rules: [uppercase]\n",
+		"exclude:\n  paths: [\"[\"]\n",
+	} {
+		if _, err := loadConfig(writeTempFile(t, content)); err == nil {
+			t.Errorf("expected error for invalid exclude config %q", content)
+		}
+	}
+}
+
+func TestCachedConfig(t *testing.T) {
+	path := writeTempFile(t, "sensitive_keywords: [ssn]\n")
+	cfg, cerr := cachedConfig(path)
+	if cerr != nil {
+		t.Fatalf("cachedConfig: %v", cerr)
+	}
+	if kw := cfg.sensitiveKeywords(); len(kw) != 1 || kw[0] != "ssn" {
+		t.Fatalf("sensitive keywords = %v, want [ssn]", kw)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	rewrite := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+
+	// an unchanged modification time keeps the cached config
+	rewrite("sensitive_keywords: [pin]\n", info.ModTime())
+	if cfg, _ := cachedConfig(path); cfg.sensitiveKeywords()[0] != "ssn" {
+		t.Error("config was loaded again although the file did not change")
+	}
+
+	later := info.ModTime().Add(time.Second)
+	rewrite("sensitive_keywords: [pin]\n", later)
+	if cfg, _ := cachedConfig(path); cfg.sensitiveKeywords()[0] != "pin" {
+		t.Error("config was not loaded again after the file changed")
+	}
+
+	rewrite("key_style:\n  style: PascalCase\n", later.Add(time.Second))
+	_, cerr = cachedConfig(path)
+	if cerr == nil || !strings.Contains(cerr.Error(), path) {
+		t.Fatalf("cachedConfig error = %v, want an error naming the config file", cerr)
+	}
+	if _, again := cachedConfig(path); again != cerr {
+		t.Error("an invalid config should return the same error for every package")
+	}
+	if cerr.report("example.com/a") == nil {
+		t.Error("the first report of a config error should return it")
+	}
+	if cerr.report("example.com/a") != nil {
+		t.Error("a config error should be reported once per package")
+	}
+	if cerr.report("example.com/b") == nil {
+		t.Error("a config error should be reported by every package using the config")
+	}
+}
+
 func TestLoadConfigInvalidPath(t *testing.T) {
 	_, err := loadConfig("/nonexistent/.loglint.yml")
 	if err == nil {
@@ -0,0 +1,214 @@
+package loglint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkConstantMessage reports log messages built at run time with fmt.Sprintf,
+// concatenation or strings.Join, whose variable parts belong in attributes.
+func checkConstantMessage(ctx *ruleContext, call *LogCall) {
+	if call.Kind != KindMessage {
+		return
+	}
+
+	info := ctx.pass.TypesInfo
+	if tv, ok := info.Types[call.Message]; ok && tv.Value != nil {
+		return
+	}
+
+	var how string
+	var text string
+	var values []ast.Expr
+	switch msg := ast.Unparen(call.Message).(type) {
+	case *ast.BinaryExpr:
+		if msg.Op != token.ADD || !hasNonLiteralParts(msg) {
+			return
+		}
+		how = "concatenation"
+		text, values = concatParts(info, msg)
+	case *ast.CallExpr:
+		fn := calleeFunc(info, msg)
+		if fn == nil || fn.Pkg() == nil {
+			return
+		}
+		switch {
+		case fn.Pkg().Path() == "fmt" && sprintFuncs[fn.Name()]:
+			// a format forwarded by a wrapper is not a message the wrapper owns
+			if fn.Name() == "Sprintf" && !isConstantFormat(info, msg) {
+				return
+			}
+			how = "fmt." + fn.Name()
+			if fn.Name() == "Sprintf" {
+				text, values = sprintfParts(info, msg)
+			}
+		case fn.Pkg().Path() == "strings" && fn.Name() == "Join":
+			how = "strings.Join"
+		default:
+			return
+		}
+	default:
+		return
+	}
+
+	ctx.report(analysis.Diagnostic{
+		Pos:            call.Message.Pos(),
+		Message:        "log message should be constant, not built with " + how,
+		SuggestedFixes: constantMessageFix(ctx.pass, call, text, values),
+	})
+}
+
+func isConstantFormat(info *types.Info, call *ast.CallExpr) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+	_, ok := constantString(info, call.Args[0])
+	return ok
+}
+
+// concatParts returns the constant text of a concatenation and the values
+// concatenated with it, or no values if one of them has no name to use as a key.
+func concatParts(info *types.Info, expr *ast.BinaryExpr) (string, []ast.Expr) {
+	var text strings.Builder
+	var values []ast.Expr
+	ok := true
+	var walk func(ast.Expr)
+	walk = func(e ast.Expr) {
+		if s, isConst := constantString(info, e); isConst {
+			text.WriteString(s)
+			return
+		}
+		if b, isAdd := ast.Unparen(e).(*ast.BinaryExpr); isAdd && b.Op == token.ADD {
+			walk(b.X)
+			walk(b.Y)
+			return
+		}
+		if _, named := keyName(info, e); !named {
+			ok = false
+		}
+		values = append(values, e)
+	}
+	walk(expr)
+
+	if !ok {
+		return "", nil
+	}
+	return text.String(), values
+}
+
+// sprintfParts returns the text of a constant fmt.Sprintf format without its verbs
+// and the formatted arguments, or no arguments if they cannot be matched to the
+// verbs one by one or one of them has no name to use as a key.
+func sprintfParts(info *types.Info, call *ast.CallExpr) (string, []ast.Expr) {
+	format, _ := constantString(info, call.Args[0])
+	args := call.Args[1:]
+	verbs := parseFormat(format)
+	if call.Ellipsis.IsValid() || len(verbs) != len(args) {
+		return "", nil
+	}
+	for i, v := range verbs {
+		if _, ok := keyName(info, args[i]); v.arg != i || !ok {
+			return "", nil
+		}
+	}
+	return strings.ReplaceAll(stripFormatVerbs(format), "%%", "%"), args
+}
+
+// constantMessageFix returns a fix replacing the message with its constant text
+// and logging the values as attributes named after them, or nil if the logger
+// has no attributes or the message has no constant text.
+func constantMessageFix(pass *analysis.Pass, call *LogCall, text string, values []ast.Expr) []analysis.SuggestedFix {
+	text = strings.TrimRight(strings.Join(strings.Fields(text), " "), " :=,")
+	if text == "" || len(values) == 0 || call.Call.Ellipsis.IsValid() {
+		return nil
+	}
+
+	edits, attr, ok := attrArgs(pass, call)
+	if !ok {
+		return nil
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		name, _ := keyName(pass.TypesInfo, v)
+		b.WriteString(", ")
+		b.WriteString(attr(strconv.Quote(name), types.ExprString(v)))
+	}
+	last := call.Call.Args[len(call.Call.Args)-1]
+	edits = append(edits,
+		analysis.TextEdit{Pos: call.Message.Pos(), End: call.Message.End(), NewText: []byte(strconv.Quote(text))},
+		analysis.TextEdit{Pos: last.End(), End: last.End(), NewText: []byte(b.String())},
+	)
+	return []analysis.SuggestedFix{{Message: "move variables to attributes", TextEdits: edits}}
+}
+
+// attrArgs returns how attributes are added to the arguments of call:
+// the edits the call needs first and the argument text for a key and value.
+// slog and zap.SugaredLogger take alternating keys and values, and the
+// SugaredLogger methods that concatenate their arguments, such as Info,
+// are replaced by their Infow variant. zap.Logger takes zap.Any fields.
+func attrArgs(pass *analysis.Pass, call *LogCall) ([]analysis.TextEdit, func(key, value string) string, bool) {
+	keyValue := func(key, value string) string { return key + ", " + value }
+	if call.KeyValues != nil {
+		return nil, keyValue, true
+	}
+
+	if call.Func.Pkg().Path() != zapPkg {
+		return nil, nil, false
+	}
+	switch receiverName(call.Func) {
+	case "SugaredLogger":
+		if edit, ok := sugaredKeyValuesEdit(call); ok {
+			return []analysis.TextEdit{edit}, keyValue, true
+		}
+	case "Logger":
+		if name, ok := importName(pass, call.Call.Pos(), zapPkg); ok {
+			return nil, func(key, value string) string {
+				return name + ".Any(" + key + ", " + value + ")"
+			}, true
+		}
+	}
+	return nil, nil, false
+}
+
+// sugaredKeyValuesEdit returns the edit renaming a zap.SugaredLogger method
+// such as Info to its key-value variant Infow.
+func sugaredKeyValuesEdit(call *LogCall) (analysis.TextEdit, bool) {
+	name := call.Func.Name()
+	if receiverName(call.Func) != "SugaredLogger" || strings.HasSuffix(name, "ln") || strings.HasSuffix(name, "f") || strings.HasSuffix(name, "w") {
+		return analysis.TextEdit{}, false
+	}
+	sel, ok := ast.Unparen(call.Call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return analysis.TextEdit{}, false
+	}
+	return analysis.TextEdit{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte(name + "w")}, true
+}
+
+// importName returns the name under which the file containing pos imports the package path.
+func importName(pass *analysis.Pass, pos token.Pos, pkgPath string) (string, bool) {
+	for _, f := range pass.Files {
+		if pos < f.FileStart || pos >= f.FileEnd {
+			continue
+		}
+		for _, spec := range f.Imports {
+			if p, err := strconv.Unquote(spec.Path.Value); err != nil || p != pkgPath {
+				continue
+			}
+			if spec.Name == nil {
+				return path.Base(pkgPath), true
+			}
+			if spec.Name.Name == "_" || spec.Name.Name == "." {
+				return "", false
+			}
+			return spec.Name.Name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,189 @@
+package loglint
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	ignoreDirective     = "//loglint:ignore"
+	fileIgnoreDirective = "//loglint:file-ignore"
+)
+
+// directive is a //loglint:ignore or //loglint:file-ignore comment.
+//
+// A //loglint:ignore comment at the end of a line applies to that line;
+// a comment on its own line applies to the next line.
+type directive struct {
+	pos       token.Pos
+	filename  string
+	line      int
+	fileLevel bool
+	rules     []string
+	reason    string
+}
+
+// parseDirective parses the text of a comment. It returns false if the comment
+// is not a loglint directive.
+func parseDirective(text string) (*directive, bool) {
+	d := &directive{}
+
+	var rest string
+	switch {
+	case strings.HasPrefix(text, fileIgnoreDirective):
+		d.fileLevel = true
+		rest = text[len(fileIgnoreDirective):]
+	case strings.HasPrefix(text, ignoreDirective):
+		rest = text[len(ignoreDirective):]
+	default:
+		return nil, false
+	}
+	if rest != "" && !unicode.IsSpace(rune(rest[0])) {
+		return nil, false
+	}
+
+	// a trailing // comment is not part of the reason
+	if i := strings.Index(rest, "//"); i >= 0 {
+		rest = rest[:i]
+	}
+	rest = strings.TrimSpace(rest)
+
+	list, reason := rest, ""
+	if i := strings.IndexFunc(rest, unicode.IsSpace); i >= 0 {
+		list, reason = rest[:i], strings.TrimSpace(rest[i:])
+	}
+	if list != "" {
+		d.rules = strings.Split(list, ",")
+	}
+	d.reason = reason
+	return d, true
+}
+
+// collectDirectives returns the loglint directives of all files in the package.
+func collectDirectives(pass *analysis.Pass) []*directive {
+	var directives []*directive
+	for _, file := range pass.Files {
+		var content []byte
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				d, ok := parseDirective(c.Text)
+				if !ok {
+					continue
+				}
+
+				pos := pass.Fset.Position(c.Pos())
+				d.pos = c.Pos()
+				d.filename = pos.Filename
+				d.line = pos.Line
+
+				if content == nil {
+					content, _ = pass.ReadFile(pos.Filename)
+				}
+				if !d.fileLevel && isOwnLine(content, pos) {
+					d.line++
+				}
+				directives = append(directives, d)
+			}
+		}
+	}
+	return directives
+}
+
+// isOwnLine reports whether only whitespace precedes pos on its line.
+func isOwnLine(content []byte, pos token.Position) bool {
+	start := pos.Offset - (pos.Column - 1)
+	if start < 0 || pos.Offset > len(content) {
+		return false
+	}
+	return strings.TrimSpace(string(content[start:pos.Offset])) == ""
+}
+
+// suppressor filters diagnostics through directives and records which directives were used.
+type suppressor struct {
+	fset       *token.FileSet
+	directives []*directive
+	used       map[*directive]map[string]bool
+}
+
+func newSuppressor(fset *token.FileSet, directives []*directive) *suppressor {
+	return &suppressor{
+		fset:       fset,
+		directives: directives,
+		used:       make(map[*directive]map[string]bool),
+	}
+}
+
+// suppresses reports whether a diagnostic of the rule at pos is ignored by a directive.
+func (s *suppressor) suppresses(rule string, pos token.Pos) bool {
+	if len(s.directives) == 0 {
+		return false
+	}
+
+	p := s.fset.Position(pos)
+	suppressed := false
+	for _, d := range s.directives {
+		if d.filename != p.Filename || (!d.fileLevel && d.line != p.Line) || !slices.Contains(d.rules, rule) {
+			continue
+		}
+		if s.used[d] == nil {
+			s.used[d] = make(map[string]bool)
+		}
+		s.used[d][rule] = true
+		suppressed = true
+	}
+	return suppressed
+}
+
+// checkDirectives reports malformed directives, directives without a reason
+// and directives that did not suppress anything.
+func checkDirectives(pass *analysis.Pass, result *Result, s *suppressor) {
+	report := func(pos token.Pos, format string, args ...interface{}) {
+		pass.Report(analysis.Diagnostic{Pos: pos, Message: fmt.Sprintf(format, args...), Category: "directives"})
+	}
+
+	for _, d := range s.directives {
+		if len(d.rules) == 0 {
+			report(d.pos, "loglint directive should list the rules to ignore")
+			continue
+		}
+
+		for _, name := range d.rules {
+			r := ruleByName(name)
+			switch {
+			case r == nil:
+				report(d.pos, "unknown rule %q in loglint directive", name)
+			case r.enabled(result.configFor(pass, d.pos).cfg) && !s.used[d][name]:
+				report(d.pos, "unused loglint directive for rule %q", name)
+			}
+		}
+
+		if d.reason == "" {
+			report(d.pos, "loglint directive should explain why the finding is ignored")
+		}
+	}
+}
+
+// ruleByOption returns the rule with the given config key, or nil.
+func ruleByOption(option string) *rule {
+	for _, r := range rules {
+		if r.option == option {
+			return r
+		}
+	}
+	return nil
+}
+
+// ruleByName returns the rule with the given name, or nil.
+func ruleByName(name string) *rule {
+	for _, r := range rules {
+		if r.name == name {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package loglint
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseDirective(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantOK    bool
+		fileLevel bool
+		rules     []string
+		reason    string
+	}{
+		{"single rule", "//loglint:ignore lowercase legacy format", true, false, []string{"lowercase"}, "legacy format"},
+		{"several rules", "//loglint:ignore lowercase,nospecial parsed by scripts", true, false, []string{"lowercase", "nospecial"}, "parsed by scripts"},
+		{"file level", "//loglint:file-ignore englishonly support team", true, true, []string{"englishonly"}, "support team"},
+		{"no reason", "//loglint:ignore lowercase", true, false, []string{"lowercase"}, ""},
+		{"no rules", "//loglint:ignore", true, false, nil, ""},
+		{"trailing comment", "//loglint:ignore lowercase // want `x`", true, false, []string{"lowercase"}, ""},
+		{"tab separated", "//loglint:ignore\tlowercase\treason", true, false, []string{"lowercase"}, "reason"},
+		{"other prefix", "//loglint:ignored lowercase", false, false, nil, ""},
+		{"space after slashes", "// loglint:ignore lowercase reason", false, false, nil, ""},
+		{"regular comment", "// regular comment", false, false, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := parseDirective(tt.text)
+			if ok != tt.wantOK {
+				t.Fatalf("parseDirective(%q) ok = %v, want %v", tt.text, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if d.fileLevel != tt.fileLevel {
+				t.Errorf("fileLevel = %v, want %v", d.fileLevel, tt.fileLevel)
+			}
+			if !slices.Equal(d.rules, tt.rules) {
+				t.Errorf("rules = %v, want %v", d.rules, tt.rules)
+			}
+			if d.reason != tt.reason {
+				t.Errorf("reason = %q, want %q", d.reason, tt.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package loglint
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// configNames are the names of the config files loglint discovers, in order of preference.
+var configNames = []string{".loglint.yml", ".loglint.yaml"}
+
+// discoverConfigs returns the config files in dir and its parents up to the
+// module root, the first directory with a go.mod file, outermost first.
+func discoverConfigs(dir string) []string {
+	var paths []string
+	for {
+		for _, name := range configNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+				paths = append(paths, path)
+				break
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	slices.Reverse(paths)
+	return paths
+}
+
+// merge returns c with the settings of a nested config, which inherits c.
+// Rules and severities are replaced one by one, loggers and overrides are
+// added to the inherited ones, and other settings are replaced when set.
+func (c Config) merge(n Config) (Config, error) {
+	c.Rules = c.Rules.merge(n.Rules)
+	if n.Keywords != nil {
+		c.Keywords = n.Keywords
+	}
+	if n.LowercaseExceptions != nil {
+		c.LowercaseExceptions = n.LowercaseExceptions
+	}
+	// the nested definitions come first to take precedence
+	c.Loggers = append(slices.Clone(n.Loggers), c.Loggers...)
+	if n.KeyStyle != (KeyStyleConfig{}) {
+		c.KeyStyle = n.KeyStyle
+	}
+	c.SpecialChars = c.SpecialChars.merge(n.SpecialChars)
+	if n.KeyRegistry != "" {
+		c.KeyRegistry, c.registry = n.KeyRegistry, n.registry
+	}
+	c.Exclude = c.Exclude.merge(n.Exclude)
+	c.Severity = mergeSeverity(c.Severity, n.Severity)
+	c.Overrides = append(slices.Clone(c.Overrides), n.Overrides...)
+
+	err := c.compile()
+	return c, err
+}
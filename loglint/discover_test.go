@@ -0,0 +1,153 @@
+package loglint
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestDiscoverConfigs(t *testing.T) {
+	root := t.TempDir()
+	module := filepath.Join(root, "module")
+	writeFile(t, filepath.Join(root, ".loglint.yml"), "")
+	writeFile(t, filepath.Join(module, "go.mod"), "module example.com/app\n")
+	writeFile(t, filepath.Join(module, ".loglint.yml"), "")
+	writeFile(t, filepath.Join(module, ".loglint.yaml"), "")
+	writeFile(t, filepath.Join(module, "a", "b", ".loglint.yaml"), "")
+
+	tests := []struct {
+		dir  string
+		want []string
+	}{
+		{module, []string{filepath.Join(module, ".loglint.yml")}},
+		{filepath.Join(module, "a"), []string{filepath.Join(module, ".loglint.yml")}},
+		{filepath.Join(module, "a", "b", "c"), []string{
+			filepath.Join(module, ".loglint.yml"),
+			filepath.Join(module, "a", "b", ".loglint.yaml"),
+		}},
+	}
+	for _, tt := range tests {
+		if got := discoverConfigs(tt.dir); !slices.Equal(got, tt.want) {
+			t.Errorf("discoverConfigs(%q) = %v, want %v", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestCachedConfigNested(t *testing.T) {
+	dir := t.TempDir()
+	parent := filepath.Join(dir, ".loglint.yml")
+	nested := filepath.Join(dir, "internal", ".loglint.yml")
+	writeFile(t, parent, `
+rules:
+  english_only: false
+  constant_message: true
+sensitive_keywords: [ssn]
+severity:
+  no_special_chars: warning
+loggers:
+  - package: example.com/log
+    methods: [Info]
+exclude:
+  paths: ["mocks/**"]
+overrides:
+  - files: ["cmd/**"]
+    rules:
+      lowercase: false
+`)
+	writeFile(t, nested, `
+rules:
+  constant_message: false
+severity:
+  lowercase: info
+loggers:
+  - package: example.com/log
+    methods: [Debug]
+overrides:
+  - files: ["legacy/**"]
+    rules:
+      key_value: false
+`)
+
+	cfg, err := cachedConfig(parent, nested)
+	if err != nil {
+		t.Fatalf("cachedConfig: %v", err)
+	}
+	if cfg.isEnglishOnlyEnabled() {
+		t.Error("english_only should be inherited from the parent config")
+	}
+	if cfg.isConstantMessageEnabled() {
+		t.Error("constant_message should be disabled by the nested config")
+	}
+	if kw := cfg.sensitiveKeywords(); len(kw) != 1 || kw[0] != "ssn" {
+		t.Errorf("sensitive keywords = %v, want [ssn]", kw)
+	}
+	if cfg.Severity["no_special_chars"] != "warning" || cfg.Severity["lowercase"] != "info" {
+		t.Errorf("severity = %v, want both levels", cfg.Severity)
+	}
+	if len(cfg.Loggers) != 2 || cfg.Loggers[0].Methods[0] != "Debug" {
+		t.Errorf("loggers = %+v, want the nested logger first", cfg.Loggers)
+	}
+
+	if len(cfg.Overrides) != 2 {
+		t.Fatalf("got %d overrides, want 2", len(cfg.Overrides))
+	}
+	if !cfg.Overrides[0].matches("", filepath.Join(dir, "cmd", "tool", "main.go")) {
+		t.Error("parent override globs should be relative to the parent config")
+	}
+	if !cfg.Overrides[1].matches("", filepath.Join(dir, "internal", "legacy", "old.go")) {
+		t.Error("nested override globs should be relative to the nested config")
+	}
+	if cfg.Overrides[1].matches("", filepath.Join(dir, "legacy", "old.go")) {
+		t.Error("nested override globs should not match outside the nested config directory")
+	}
+	if relativeName(cfg.Exclude.dir, filepath.Join(dir, "mocks", "m.go")) != "mocks/m.go" {
+		t.Error("inherited exclude paths should stay relative to the parent config")
+	}
+
+	again, _ := cachedConfig(parent, nested)
+	if again.style != cfg.style {
+		t.Error("the merged config should be cached")
+	}
+}
+
+func TestCachedConfigNestedSpecialChars(t *testing.T) {
+	dir := t.TempDir()
+	parent := filepath.Join(dir, ".loglint.yml")
+	nested := filepath.Join(dir, "internal", ".loglint.yml")
+	writeFile(t, parent, "special_chars:\n  allowed: [hyphen]\n  allow_emoji: true\n")
+	writeFile(t, nested, "special_chars:\n  allow_emoji: false\n")
+
+	cfg, err := cachedConfig(parent, nested)
+	if err != nil {
+		t.Fatalf("cachedConfig: %v", err)
+	}
+	if cfg.chars.emoji {
+		t.Error("a nested config should be able to disallow emoji allowed by the parent")
+	}
+	if !cfg.chars.allowed['-'] {
+		t.Error("allowed characters should be inherited from the parent config")
+	}
+}
+
+func TestCachedConfigNestedInvalid(t *testing.T) {
+	dir := t.TempDir()
+	parent := filepath.Join(dir, ".loglint.yml")
+	nested := filepath.Join(dir, "internal", ".loglint.yml")
+	writeFile(t, parent, "rules:\n  lowercase: false\n")
+	writeFile(t, nested, "severity:\n  lowercase: fatal\n")
+
+	if _, err := cachedConfig(parent, nested); err == nil || err.path != nested {
+		t.Errorf("cachedConfig error = %v, want an error for %s", err, nested)
+	}
+}
@@ -0,0 +1,124 @@
+package loglint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+)
+
+// checkDuplicateKeys reports constant keys set twice by a call, counting the keys
+// of the With calls it is directly chained to. Only keys set by the call itself
+// are reported, so a duplicate is reported once even when several calls are chained.
+func checkDuplicateKeys(ctx *ruleContext, call *LogCall) {
+	if call.Kind != KindMessage && call.Kind != KindFields {
+		return
+	}
+
+	info := ctx.pass.TypesInfo
+	seen := make(map[string]bool)
+	for _, key := range receiverKeys(info, call.Call) {
+		if s, ok := constantString(info, key); ok {
+			seen[s] = true
+		}
+	}
+
+	for _, key := range ownKeys(info, call) {
+		s, ok := constantString(info, key)
+		if !ok {
+			continue
+		}
+		if seen[s] {
+			ctx.reportf(key.Pos(), "duplicate log key %q", s)
+		}
+		seen[s] = true
+	}
+}
+
+// ownKeys returns the keys set by the arguments of call.
+func ownKeys(info *types.Info, call *LogCall) []ast.Expr {
+	switch {
+	case call.Kind == KindFields && call.Func.Pkg().Path() == logrusPkg:
+		return attrKeys(logrusFields(call.Call))
+	case call.Kind == KindFields:
+		return argKeys(info, call.Call.Args, true)
+	case call.KeyValues != nil:
+		return argKeys(info, call.KeyValues, true)
+	}
+
+	for i, arg := range call.Call.Args {
+		if arg == call.Message {
+			return argKeys(info, call.Call.Args[i+1:], false)
+		}
+	}
+	return nil
+}
+
+// receiverKeys returns the keys set by the With calls that call is chained to,
+// outermost first. The chain ends at a call that is not a With and at
+// slog.Logger.WithGroup, which starts a new namespace for the keys after it.
+func receiverKeys(info *types.Info, call *ast.CallExpr) []ast.Expr {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	recv, ok := ast.Unparen(sel.X).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	fn := calleeFunc(info, recv)
+	if fn == nil || fn.Pkg() == nil {
+		return nil
+	}
+
+	var keys []ast.Expr
+	switch path := fn.Pkg().Path(); {
+	case path == logrusPkg && (fn.Name() == "WithField" || fn.Name() == "WithFields"):
+		keys = attrKeys(logrusFields(recv))
+	case (path == slogPkg || path == zapPkg) && fn.Name() == "With":
+		keys = argKeys(info, recv.Args, true)
+	default:
+		return nil
+	}
+	return append(receiverKeys(info, recv), keys...)
+}
+
+// argKeys returns the keys set by args, including the keys of attribute
+// constructors passed directly. If pairs is false, only attributes are read;
+// otherwise the other arguments are alternating keys and values.
+// Keys nested in slog.Group are in their own namespace and are not returned.
+func argKeys(info *types.Info, args []ast.Expr, pairs bool) []ast.Expr {
+	var keys []ast.Expr
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if isAttrType(info.TypeOf(arg)) {
+			if c, ok := ast.Unparen(arg).(*ast.CallExpr); ok && len(c.Args) > 0 {
+				if fn := calleeFunc(info, c); fn != nil && fn.Pkg() != nil && isAttrConstructor(fn) {
+					keys = append(keys, c.Args[0])
+				}
+			}
+			continue
+		}
+		if pairs {
+			keys = append(keys, arg)
+			i++
+		}
+	}
+	return keys
+}
+
+func attrKeys(attrs []Attr) []ast.Expr {
+	keys := make([]ast.Expr, 0, len(attrs))
+	for _, a := range attrs {
+		keys = append(keys, a.Key)
+	}
+	return keys
+}
+
+// constantString returns the value of expr if it is a constant string.
+func constantString(info *types.Info, expr ast.Expr) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
@@ -0,0 +1,98 @@
+package loglint
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ExcludeConfig controls which files are not checked.
+type ExcludeConfig struct {
+	// Generated excludes files with a "// Code generated ... DO NOT EDIT." header.
+	// It is enabled by default.
+	Generated *bool `yaml:"generated"`
+	// Tests excludes _test.go files from all rules but TestRules.
+	// It is enabled by default.
+	Tests *bool `yaml:"tests"`
+	// TestRules are the rules, as in Rules, that still check test files.
+	TestRules []string `yaml:"test_rules"`
+	// Paths are file globs relative to the directory of the config file,
+	// as in Override.Files.
+	Paths []string `yaml:"paths"`
+
+	// dir is the directory of the config file that set Paths.
+	dir string
+}
+
+func (e ExcludeConfig) validate() error {
+	for _, option := range e.TestRules {
+		if ruleByOption(option) == nil {
+			return fmt.Errorf("exclude: unknown rule %q in test_rules", option)
+		}
+	}
+	for _, p := range e.Paths {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("exclude: invalid path glob %q", p)
+		}
+	}
+	return nil
+}
+
+// merge returns e with the settings of a nested config replaced.
+func (e ExcludeConfig) merge(n ExcludeConfig) ExcludeConfig {
+	if n.Generated != nil {
+		e.Generated = n.Generated
+	}
+	if n.Tests != nil {
+		e.Tests = n.Tests
+	}
+	if n.TestRules != nil {
+		e.TestRules = n.TestRules
+	}
+	if n.Paths != nil {
+		e.Paths, e.dir = n.Paths, n.dir
+	}
+	return e
+}
+
+func (e ExcludeConfig) excludesGenerated() bool {
+	return e.Generated == nil || *e.Generated
+}
+
+func (e ExcludeConfig) excludesTests() bool {
+	return e.Tests == nil || *e.Tests
+}
+
+// excludes reports whether no rule checks the file.
+func (e ExcludeConfig) excludes(f *ast.File, filename string) bool {
+	if e.excludesGenerated() && ast.IsGenerated(f) {
+		return true
+	}
+	filename = relativeName(e.dir, filename)
+	for _, p := range e.Paths {
+		if matchGlob(p, filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// testRules returns the rules config that disables the rules which do not check test files.
+func (e ExcludeConfig) testRules() RulesConfig {
+	var r RulesConfig
+	disabled := false
+	v := reflect.ValueOf(&r).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if !slices.Contains(e.TestRules, v.Type().Field(i).Tag.Get("yaml")) {
+			v.Field(i).Set(reflect.ValueOf(&disabled))
+		}
+	}
+	return r
+}
+
+func isTestFile(filename string) bool {
+	return strings.HasSuffix(filename, "_test.go")
+}
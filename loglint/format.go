@@ -0,0 +1,391 @@
+package loglint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// formatVerb is a verb of a printf-style format string.
+type formatVerb struct {
+	verb  rune
+	flags string
+	// arg is the index of the argument formatted by the verb, counted from the first argument after the format.
+	// It is -1 if the directive has an invalid argument index such as %[0]d.
+	arg int
+	// start and end are the byte offsets of the directive, from % to the verb.
+	start, end int
+	// indexed reports whether the directive uses an explicit argument index such as %[2]d.
+	indexed bool
+}
+
+// parseFormat returns the verbs of a printf-style format string.
+// Arguments consumed by * widths and precisions are skipped, and explicit
+// argument indexes such as %[2]d are honored. %% is not a verb.
+func parseFormat(format string) []formatVerb {
+	var verbs []formatVerb
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		start := i
+		indexed, badIndex := false, false
+		i++
+
+		var flags strings.Builder
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			flags.WriteByte(format[i])
+			i++
+		}
+
+		// width, precision and argument indexes, in any order
+	spec:
+		for i < len(format) {
+			c := format[i]
+			switch {
+			case c == '[':
+				end := strings.IndexByte(format[i:], ']')
+				if end < 0 {
+					return verbs
+				}
+				if n, ok := atoi(format[i+1 : i+end]); ok && n >= 1 {
+					arg = n - 1
+				} else {
+					badIndex = true
+				}
+				indexed = true
+				i += end + 1
+			case c == '*':
+				arg++
+				i++
+			case c == '.' || (c >= '0' && c <= '9'):
+				i++
+			default:
+				break spec
+			}
+		}
+		if i >= len(format) {
+			return verbs
+		}
+		r, size := utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+		if r == '%' {
+			continue
+		}
+		v := formatVerb{
+			verb:    r,
+			flags:   flags.String(),
+			arg:     arg,
+			start:   start,
+			end:     i + 1,
+			indexed: indexed,
+		}
+		if badIndex {
+			v.arg = -1
+			verbs = append(verbs, v)
+			continue
+		}
+		verbs = append(verbs, v)
+		arg++
+	}
+	return verbs
+}
+
+func atoi(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	n := 0
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+// stripFormatVerbs returns the text of a printf-style format without its verbs.
+func stripFormatVerbs(format string) string {
+	var b strings.Builder
+	last := 0
+	for _, v := range parseFormat(format) {
+		b.WriteString(format[last:v.start])
+		last = v.end
+	}
+	b.WriteString(format[last:])
+	return b.String()
+}
+
+// mapFormatText applies f to the text between the verbs of a printf-style format,
+// keeping the verbs and %% escapes unchanged.
+func mapFormatText(format string, f func(string) string) string {
+	text := func(s string) string {
+		parts := strings.Split(s, "%%")
+		for i, part := range parts {
+			parts[i] = f(part)
+		}
+		return strings.Join(parts, "%%")
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, v := range parseFormat(format) {
+		b.WriteString(text(format[last:v.start]))
+		b.WriteString(format[v.start:v.end])
+		last = v.end
+	}
+	b.WriteString(text(format[last:]))
+	return b.String()
+}
+
+// formatCallArgs returns the arguments formatted by a printf-style call.
+func formatCallArgs(call *LogCall) []ast.Expr {
+	for i, arg := range call.Call.Args {
+		if arg == call.Message {
+			return call.Call.Args[i+1:]
+		}
+	}
+	return nil
+}
+
+// formatArgs returns the arguments formatted with the verb by a printf-style call
+// with a constant format.
+func formatArgs(info *types.Info, call *LogCall, verb rune) []ast.Expr {
+	format, ok := constantString(info, call.Message)
+	if !ok || call.Call.Ellipsis.IsValid() {
+		return nil
+	}
+
+	rest := formatCallArgs(call)
+	var args []ast.Expr
+	for _, v := range parseFormat(format) {
+		if v.verb == verb && v.arg >= 0 && v.arg < len(rest) {
+			args = append(args, rest[v.arg])
+		}
+	}
+	return args
+}
+
+// checkPrintf checks the verbs of a constant printf-style format against the arguments.
+func checkPrintf(ctx *ruleContext, call *LogCall) {
+	if !call.Format || call.Call.Ellipsis.IsValid() {
+		return
+	}
+	info := ctx.pass.TypesInfo
+	format, ok := constantString(info, call.Message)
+	if !ok {
+		return
+	}
+
+	args := formatCallArgs(call)
+	verbs := parseFormat(format)
+	read, indexed := 0, false
+	for _, v := range verbs {
+		indexed = indexed || v.indexed
+		read = max(read, v.arg+1)
+		if v.arg < 0 {
+			ctx.reportf(call.Message.Pos(), "log format %s has invalid argument index for %%%c", quoteFormat(format), v.verb)
+			continue
+		}
+		if !strings.ContainsRune(printfVerbs, v.verb) {
+			ctx.reportf(call.Message.Pos(), "log format %s has unknown verb %%%c", quoteFormat(format), v.verb)
+			continue
+		}
+		if v.arg >= len(args) {
+			ctx.reportf(call.Message.Pos(), "log format %s reads arg #%d, but call has %d args", quoteFormat(format), v.arg+1, len(args))
+			continue
+		}
+
+		arg := args[v.arg]
+		if t := info.TypeOf(arg); t != nil && !verbAccepts(v.verb, t) {
+			ctx.reportf(arg.Pos(), "log format %%%c has arg %s of wrong type %s",
+				v.verb, types.ExprString(arg), types.TypeString(t, types.RelativeTo(ctx.pass.Pkg)))
+		}
+	}
+
+	if !indexed && read < len(args) {
+		ctx.reportf(call.Message.Pos(), "log format %s reads %d args, but call has %d args", quoteFormat(format), read, len(args))
+	}
+}
+
+// checkFormatVerbs reports printf-style verbs in the constant messages of slog
+// and zap methods that do not format their arguments, where they are logged
+// literally. If every verb has its argument, the fix turns the arguments into
+// attributes named after them.
+func checkFormatVerbs(ctx *ruleContext, call *LogCall) {
+	msg, verbs := misplacedVerbs(ctx.pass.TypesInfo, call)
+	if len(verbs) == 0 {
+		return
+	}
+
+	ctx.report(analysis.Diagnostic{
+		Pos:            call.Message.Pos(),
+		Message:        fmt.Sprintf("log message has formatting directive %s, but %s does not format its arguments", msg[verbs[0].start:verbs[0].end], call.Func.Name()),
+		SuggestedFixes: formatVerbsFix(ctx.pass.TypesInfo, call, msg, verbs),
+	})
+}
+
+// misplacedVerbs returns the constant message of a slog or zap method that
+// does not format its arguments, and the printf-style verbs in it.
+func misplacedVerbs(info *types.Info, call *LogCall) (string, []formatVerb) {
+	if call.Kind != KindMessage || call.Format {
+		return "", nil
+	}
+	if path := call.Func.Pkg().Path(); path != slogPkg && path != zapPkg {
+		return "", nil
+	}
+	msg, ok := constantString(info, call.Message)
+	if !ok {
+		return "", nil
+	}
+
+	var verbs []formatVerb
+	for _, v := range parseFormat(msg) {
+		// "100% sure" is text, not a % s directive
+		if strings.ContainsRune(printfVerbs, v.verb) && !strings.ContainsAny(v.flags, " 0") {
+			verbs = append(verbs, v)
+		}
+	}
+	return msg, verbs
+}
+
+// formatVerbsFix returns a fix removing the verbs from the message and adding
+// keys before their arguments, or nil if an argument is missing or has no name.
+// zap.SugaredLogger methods such as Info concatenate their arguments,
+// so they are replaced by the Infow variant.
+func formatVerbsFix(info *types.Info, call *LogCall, msg string, verbs []formatVerb) []analysis.SuggestedFix {
+	lit, ok := call.Message.(*ast.BasicLit)
+	if !ok {
+		return nil
+	}
+
+	var edits []analysis.TextEdit
+	if call.KeyValues == nil {
+		edit, ok := sugaredKeyValuesEdit(call)
+		if !ok {
+			return nil
+		}
+		edits = append(edits, edit)
+	}
+
+	args := formatCallArgs(call)
+	if call.Call.Ellipsis.IsValid() || len(args) < len(verbs) {
+		return nil
+	}
+	for i, v := range verbs {
+		name, ok := keyName(info, args[i])
+		if v.arg != i || !ok || isAttrType(info.TypeOf(args[i])) {
+			return nil
+		}
+		edits = append(edits, analysis.TextEdit{Pos: args[i].Pos(), End: args[i].Pos(), NewText: []byte(strconv.Quote(name) + ", ")})
+	}
+
+	text := strings.Join(strings.Fields(stripFormatVerbs(msg)), " ")
+	text = strings.TrimRight(text, " :=,")
+	edits = append(edits, analysis.TextEdit{Pos: lit.Pos(), End: lit.End(), NewText: []byte(strconv.Quote(text))})
+
+	return []analysis.SuggestedFix{{Message: "move format arguments to attributes", TextEdits: edits}}
+}
+
+// printfVerbs are the verbs understood by the fmt package, except %w.
+const printfVerbs = "bcdeEfFgGoOpqstTUvxX"
+
+func quoteFormat(format string) string {
+	return "\"" + strings.ReplaceAll(format, "\"", "\\\"") + "\""
+}
+
+// verbAccepts reports whether a value of type t can be formatted with verb,
+// following the rules of the printf vet check in a simplified form:
+// composite values are accepted if their elements are.
+func verbAccepts(verb rune, t types.Type) bool {
+	return verbAcceptsType(verb, t, make(map[types.Type]bool))
+}
+
+func verbAcceptsType(verb rune, t types.Type, seen map[types.Type]bool) bool {
+	if verb == 'v' || verb == 'T' || types.IsInterface(t) {
+		return true
+	}
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+
+	if strings.ContainsRune("sqxX", verb) && hasStringMethod(t) {
+		return true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return basicAccepts(verb, u)
+	case *types.Pointer:
+		if strings.ContainsRune("pbdoxX", verb) {
+			return true
+		}
+		switch u.Elem().Underlying().(type) {
+		case *types.Struct, *types.Array, *types.Slice, *types.Map:
+			return verbAcceptsType(verb, u.Elem(), seen)
+		}
+		return false
+	case *types.Slice:
+		if verb == 'p' {
+			return true
+		}
+		if b, ok := u.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Byte && strings.ContainsRune("sqxX", verb) {
+			return true
+		}
+		return verbAcceptsType(verb, u.Elem(), seen)
+	case *types.Array:
+		return verbAcceptsType(verb, u.Elem(), seen)
+	case *types.Map:
+		return verb == 'p' || (verbAcceptsType(verb, u.Key(), seen) && verbAcceptsType(verb, u.Elem(), seen))
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if !verbAcceptsType(verb, u.Field(i).Type(), seen) {
+				return false
+			}
+		}
+		return true
+	case *types.Chan, *types.Signature:
+		return verb == 'p'
+	}
+	return false
+}
+
+func basicAccepts(verb rune, b *types.Basic) bool {
+	info := b.Info()
+	integer := info&types.IsInteger != 0
+	float := info&(types.IsFloat|types.IsComplex) != 0
+	switch verb {
+	case 'b':
+		return integer || float
+	case 'c', 'U', 'd', 'o', 'O':
+		return integer
+	case 'x', 'X':
+		return integer || float || info&types.IsString != 0
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return float
+	case 's':
+		return info&types.IsString != 0
+	case 'q':
+		return info&types.IsString != 0 || integer
+	case 't':
+		return info&types.IsBoolean != 0
+	case 'p':
+		return b.Kind() == types.UnsafePointer
+	}
+	return false
+}
+
+// hasStringMethod reports whether t or *t has a String or Error method,
+// so that fmt formats it as a string.
+func hasStringMethod(t types.Type) bool {
+	mset := types.NewMethodSet(types.NewPointer(t))
+	return hasMethod(mset, "String", 0, 1) || hasMethod(mset, "Error", 0, 1)
+}
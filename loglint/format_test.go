@@ -0,0 +1,80 @@
+package loglint
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   []formatVerb
+	}{
+		{"no verbs", "plain text", nil},
+		{"percent", "100%% done", nil},
+		{"sequential", "user %s has %d items", []formatVerb{
+			{verb: 's', arg: 0, start: 5, end: 7},
+			{verb: 'd', arg: 1, start: 12, end: 14},
+		}},
+		{"flags", "%+v %#x", []formatVerb{
+			{verb: 'v', flags: "+", arg: 0, start: 0, end: 3},
+			{verb: 'x', flags: "#", arg: 1, start: 4, end: 7},
+		}},
+		{"width and precision", "%-10s %6.2f", []formatVerb{
+			{verb: 's', flags: "-", arg: 0, start: 0, end: 5},
+			{verb: 'f', arg: 1, start: 6, end: 11},
+		}},
+		{"star width", "%*d", []formatVerb{{verb: 'd', arg: 1, start: 0, end: 3}}},
+		{"explicit index", "%[2]s %[1]d", []formatVerb{
+			{verb: 's', arg: 1, start: 0, end: 5, indexed: true},
+			{verb: 'd', arg: 0, start: 6, end: 11, indexed: true},
+		}},
+		{"invalid index", "%[0]v %s", []formatVerb{
+			{verb: 'v', arg: -1, start: 0, end: 5, indexed: true},
+			{verb: 's', arg: 0, start: 6, end: 8},
+		}},
+		{"non-numeric index", "%[x]v", []formatVerb{{verb: 'v', arg: -1, start: 0, end: 5, indexed: true}}},
+		{"truncated", "value %", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseFormat(tt.format); !slices.Equal(got, tt.want) {
+				t.Errorf("parseFormat(%q) = %v, want %v", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripFormatVerbs(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"plain text", "plain text"},
+		{"user %s has %d items", "user  has  items"},
+		{"100%% done", "100%% done"},
+		{"%-10s: %6.2f%%", ": %%"},
+	}
+	for _, tt := range tests {
+		if got := stripFormatVerbs(tt.format); got != tt.want {
+			t.Errorf("stripFormatVerbs(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestMapFormatText(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"user: %s, items: %d!", "user %s items %d"},
+		{"100%% done!", "100%% done"},
+		{"progress: %d%%", "progress %d%%"},
+	}
+	for _, tt := range tests {
+		if got := mapFormatText(tt.format, specialChars{}.stripSpecialChars); got != tt.want {
+			t.Errorf("mapFormatText(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
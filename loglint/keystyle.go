@@ -0,0 +1,166 @@
+package loglint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// KeyStyleConfig configures the naming convention of structured log keys.
+type KeyStyleConfig struct {
+	// Style is snake_case, camelCase or kebab-case. The default is snake_case.
+	Style string `yaml:"style"`
+	// Pattern is a regular expression keys must match. It takes precedence over Style.
+	Pattern string `yaml:"pattern"`
+}
+
+const defaultKeyStyle = "snake_case"
+
+// keyStyle is a compiled KeyStyleConfig. convert is nil for custom patterns,
+// which cannot be fixed automatically.
+type keyStyle struct {
+	name    string
+	re      *regexp.Regexp
+	convert func(words []string) string
+}
+
+var keyStyles = map[string]keyStyle{
+	"snake_case": {
+		re: dottedKeyRegexp(`[a-z0-9]+(_[a-z0-9]+)*`),
+		convert: func(words []string) string {
+			return strings.ToLower(strings.Join(words, "_"))
+		},
+	},
+	"kebab-case": {
+		re: dottedKeyRegexp(`[a-z0-9]+(-[a-z0-9]+)*`),
+		convert: func(words []string) string {
+			return strings.ToLower(strings.Join(words, "-"))
+		},
+	},
+	"camelCase": {
+		re: dottedKeyRegexp(`[a-z][a-zA-Z0-9]*`),
+		convert: func(words []string) string {
+			var b strings.Builder
+			for i, w := range words {
+				w = strings.ToLower(w)
+				if i > 0 && w != "" {
+					w = strings.ToUpper(w[:1]) + w[1:]
+				}
+				b.WriteString(w)
+			}
+			return b.String()
+		},
+	},
+}
+
+// dottedKeyRegexp matches keys made of dot-separated segments, such as http.status_code.
+func dottedKeyRegexp(segment string) *regexp.Regexp {
+	return regexp.MustCompile(`^` + segment + `(\.` + segment + `)*$`)
+}
+
+func newKeyStyle(cfg KeyStyleConfig) (*keyStyle, error) {
+	if cfg.Pattern != "" {
+		re, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("key_style: invalid pattern: %w", err)
+		}
+		return &keyStyle{name: "match " + cfg.Pattern, re: re}, nil
+	}
+
+	name := cfg.Style
+	if name == "" {
+		name = defaultKeyStyle
+	}
+	s, ok := keyStyles[name]
+	if !ok {
+		return nil, fmt.Errorf("key_style: unknown style %q", name)
+	}
+	s.name = "be " + name
+	return &s, nil
+}
+
+// fix returns key converted to the style, keeping dot-separated segments.
+func (s *keyStyle) fix(key string) (string, bool) {
+	if s.convert == nil {
+		return "", false
+	}
+	segments := strings.Split(key, ".")
+	for i, seg := range segments {
+		words := splitWords(seg)
+		if len(words) == 0 {
+			return "", false
+		}
+		segments[i] = s.convert(words)
+	}
+	fixed := strings.Join(segments, ".")
+	return fixed, s.re.MatchString(fixed)
+}
+
+// splitWords splits a key in any common style into words: "userID", "user_id"
+// and "user-id" all give [user ID] or [user id]. A run of capitals is one word,
+// so "HTTPStatus" gives [HTTP Status].
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+		prev := runes[i-1]
+		lowerToUpper := unicode.IsUpper(r) && !unicode.IsUpper(prev)
+		acronymEnd := unicode.IsUpper(r) && unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if lowerToUpper || acronymEnd {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// checkKeyStyle reports constant keys that do not follow the configured style.
+func checkKeyStyle(ctx *ruleContext, call *LogCall) {
+	style := ctx.keyStyle()
+	if style == nil {
+		return
+	}
+
+	for _, a := range call.Attrs {
+		tv, ok := ctx.pass.TypesInfo.Types[a.Key]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			continue
+		}
+		key := constant.StringVal(tv.Value)
+		if style.re.MatchString(key) {
+			continue
+		}
+
+		d := analysis.Diagnostic{
+			Pos:     a.Key.Pos(),
+			Message: fmt.Sprintf("log key %q should %s", key, style.name),
+		}
+		if lit, ok := ast.Unparen(a.Key).(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if fixed, ok := style.fix(key); ok {
+				d.SuggestedFixes = suggestedFix("rename log key", lit, fixed)
+			}
+		}
+		ctx.report(d)
+	}
+}
@@ -0,0 +1,67 @@
+package loglint
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		key  string
+		want []string
+	}{
+		{"user_id", []string{"user", "id"}},
+		{"user-id", []string{"user", "id"}},
+		{"userID", []string{"user", "ID"}},
+		{"HTTPStatus", []string{"HTTP", "Status"}},
+		{"requestCount2", []string{"request", "Count2"}},
+		{"user", []string{"user"}},
+		{"__", nil},
+	}
+	for _, tt := range tests {
+		if got := splitWords(tt.key); !slices.Equal(got, tt.want) {
+			t.Errorf("splitWords(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestKeyStyleFix(t *testing.T) {
+	tests := []struct {
+		style string
+		key   string
+		want  string
+	}{
+		{"snake_case", "userID", "user_id"},
+		{"snake_case", "http.statusCode", "http.status_code"},
+		{"snake_case", "user-name", "user_name"},
+		{"kebab-case", "user_name", "user-name"},
+		{"camelCase", "user_id", "userId"},
+		{"camelCase", "HTTPStatus", "httpStatus"},
+	}
+	for _, tt := range tests {
+		s, err := newKeyStyle(KeyStyleConfig{Style: tt.style})
+		if err != nil {
+			t.Fatalf("newKeyStyle(%q): %v", tt.style, err)
+		}
+		if s.re.MatchString(tt.key) {
+			t.Errorf("%s: key %q should not match", tt.style, tt.key)
+		}
+		got, ok := s.fix(tt.key)
+		if !ok || got != tt.want {
+			t.Errorf("%s: fix(%q) = %q, %v, want %q", tt.style, tt.key, got, ok, tt.want)
+		}
+	}
+}
+
+func TestKeyStylePattern(t *testing.T) {
+	s, err := newKeyStyle(KeyStyleConfig{Style: "camelCase", Pattern: "^[a-z]+$"})
+	if err != nil {
+		t.Fatalf("newKeyStyle: %v", err)
+	}
+	if !s.re.MatchString("user") || s.re.MatchString("userId") {
+		t.Error("pattern should take precedence over style")
+	}
+	if _, ok := s.fix("user_id"); ok {
+		t.Error("keys should not be fixed for a custom pattern")
+	}
+}
@@ -0,0 +1,153 @@
+package loglint
+
+import (
+	"fmt"
+	"go/types"
+	"regexp"
+)
+
+const (
+	slogPkg   = "log/slog"
+	zapPkg    = "go.uber.org/zap"
+	logrusPkg = "github.com/sirupsen/logrus"
+)
+
+// LoggerConfig describes a logger whose calls are checked.
+type LoggerConfig struct {
+	// Package is the import path of the package declaring the function or method.
+	Package string `yaml:"package"`
+	// Receiver is the name of the receiver type. Empty matches package-level
+	// functions and methods of any type.
+	Receiver string `yaml:"receiver"`
+	// Methods lists the function or method names.
+	Methods []string `yaml:"methods"`
+	// MethodsRegex matches function or method names in addition to Methods.
+	MethodsRegex string `yaml:"methods_regex"`
+	// MessageIndex is the index of the message argument.
+	MessageIndex int `yaml:"message_index"`
+	// KeyValues marks the arguments after the message as alternating keys and values.
+	KeyValues bool `yaml:"key_values"`
+	// Format marks the message as a printf-style format for the arguments after it.
+	Format bool `yaml:"format"`
+}
+
+// defaultLoggers are the built-in logger definitions.
+var defaultLoggers = []LoggerConfig{
+	{Package: slogPkg, Methods: []string{"Debug", "Info", "Warn", "Error"}, KeyValues: true},
+	{Package: slogPkg, Methods: []string{"DebugContext", "InfoContext", "WarnContext", "ErrorContext"}, MessageIndex: 1, KeyValues: true},
+	{Package: slogPkg, Methods: []string{"Log"}, MessageIndex: 2, KeyValues: true},
+	{Package: slogPkg, Methods: []string{"LogAttrs"}, MessageIndex: 2},
+
+	{Package: zapPkg, Methods: []string{
+		"Debug", "Info", "Warn", "Error", "DPanic", "Panic", "Fatal",
+		"Debugln", "Infoln", "Warnln", "Errorln", "DPanicln", "Panicln", "Fatalln",
+	}},
+	{Package: zapPkg, Methods: []string{
+		"Debugf", "Infof", "Warnf", "Errorf", "DPanicf", "Panicf", "Fatalf",
+	}, Format: true},
+	{Package: zapPkg, Methods: []string{
+		"Debugw", "Infow", "Warnw", "Errorw", "DPanicw", "Panicw", "Fatalw",
+	}, KeyValues: true},
+
+	{Package: "log", Methods: []string{"Print", "Println", "Fatal", "Fatalln", "Panic", "Panicln"}},
+	{Package: "log", Methods: []string{"Printf", "Fatalf", "Panicf"}, Format: true},
+	{Package: "log", Methods: []string{"Output"}, MessageIndex: 1},
+
+	{Package: logrusPkg, Methods: []string{
+		"Trace", "Debug", "Info", "Print", "Warn", "Warning", "Error", "Fatal", "Panic",
+		"Traceln", "Debugln", "Infoln", "Println", "Warnln", "Warningln", "Errorln", "Fatalln", "Panicln",
+	}},
+	{Package: logrusPkg, Methods: []string{
+		"Tracef", "Debugf", "Infof", "Printf", "Warnf", "Warningf", "Errorf", "Fatalf", "Panicf",
+	}, Format: true},
+	{Package: logrusPkg, Methods: []string{"Log", "Logln"}, MessageIndex: 1},
+	{Package: logrusPkg, Methods: []string{"Logf"}, MessageIndex: 1, Format: true},
+
+	{Package: zerologPkg, Receiver: "Event", Methods: []string{"Msg"}},
+	{Package: zerologPkg, Receiver: "Event", Methods: []string{"Msgf"}, Format: true},
+	{Package: zerologPkg, Receiver: "Logger", Methods: []string{"Print"}},
+	{Package: zerologPkg, Receiver: "Logger", Methods: []string{"Printf"}, Format: true},
+	{Package: zerologPkg + "/log", Methods: []string{"Print"}},
+	{Package: zerologPkg + "/log", Methods: []string{"Printf"}, Format: true},
+}
+
+// loggerArgs describes the arguments of a logger call.
+type loggerArgs struct {
+	msgIndex  int
+	keyValues bool
+	format    bool
+}
+
+// loggerMatcher is a compiled LoggerConfig.
+type loggerMatcher struct {
+	receiver string
+	methods  map[string]bool
+	re       *regexp.Regexp
+	args     loggerArgs
+}
+
+// loggerSet matches functions against logger definitions, indexed by package path.
+type loggerSet map[string][]loggerMatcher
+
+// newLoggerSet compiles logger definitions. Earlier definitions take precedence.
+func newLoggerSet(loggers []LoggerConfig) (loggerSet, error) {
+	set := make(loggerSet)
+	for i, l := range loggers {
+		if l.Package == "" {
+			return nil, fmt.Errorf("loggers[%d]: package is required", i)
+		}
+		if len(l.Methods) == 0 && l.MethodsRegex == "" {
+			return nil, fmt.Errorf("loggers[%d]: methods or methods_regex is required", i)
+		}
+		if l.MessageIndex < 0 {
+			return nil, fmt.Errorf("loggers[%d]: message_index must not be negative", i)
+		}
+
+		m := loggerMatcher{
+			receiver: l.Receiver,
+			methods:  make(map[string]bool, len(l.Methods)),
+			args:     loggerArgs{msgIndex: l.MessageIndex, keyValues: l.KeyValues, format: l.Format},
+		}
+		for _, name := range l.Methods {
+			m.methods[name] = true
+		}
+		if l.MethodsRegex != "" {
+			re, err := regexp.Compile(l.MethodsRegex)
+			if err != nil {
+				return nil, fmt.Errorf("loggers[%d]: invalid methods_regex: %w", i, err)
+			}
+			m.re = re
+		}
+		set[l.Package] = append(set[l.Package], m)
+	}
+	return set, nil
+}
+
+// match returns the arguments of fn if it is one of the defined loggers.
+func (s loggerSet) match(fn *types.Func) (loggerArgs, bool) {
+	for _, m := range s[fn.Pkg().Path()] {
+		if m.receiver != "" && receiverName(fn) != m.receiver {
+			continue
+		}
+		if m.methods[fn.Name()] || (m.re != nil && m.re.MatchString(fn.Name())) {
+			return m.args, true
+		}
+	}
+	return loggerArgs{}, false
+}
+
+// receiverName returns the name of the receiver type of a method, or "" for functions.
+func receiverName(fn *types.Func) string {
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return ""
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return ""
+}
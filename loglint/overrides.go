@@ -0,0 +1,245 @@
+package loglint
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"maps"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Override changes the configuration for the packages and files it matches.
+// Overrides are applied in order, so later ones take precedence.
+type Override struct {
+	// Packages are package path patterns. A pattern ending in /... matches
+	// the package and all packages below it; others use path.Match syntax.
+	Packages []string `yaml:"packages"`
+	// Files are file globs relative to the directory of the config file.
+	// ** matches any number of directories.
+	Files []string `yaml:"files"`
+
+	Rules    RulesConfig       `yaml:"rules"`
+	Keywords []string          `yaml:"sensitive_keywords"`
+	Severity map[string]string `yaml:"severity"`
+
+	// dir is the directory of the config file declaring the override.
+	dir string
+}
+
+// severities are the accepted severity levels. Diagnostics of rules with
+// a level other than error are prefixed with it.
+var severities = []string{"error", "warning", "info"}
+
+// category returns the category of a diagnostic of the rule reported at the
+// level: the rule name for errors, "rule:level" otherwise.
+func category(rule, level string) string {
+	if level == "error" {
+		return rule
+	}
+	return rule + ":" + level
+}
+
+// Rule returns the name of the rule that reported a loglint diagnostic.
+func Rule(d analysis.Diagnostic) string {
+	rule, _ := splitCategory(d.Category)
+	return rule
+}
+
+// Severity returns the severity level of a loglint diagnostic, so drivers can
+// keep warnings and infos out of the exit status.
+func Severity(d analysis.Diagnostic) string {
+	_, level := splitCategory(d.Category)
+	return level
+}
+
+func splitCategory(c string) (rule, level string) {
+	rule, level, ok := strings.Cut(c, ":")
+	if !ok || !slices.Contains(severities, level) {
+		return c, "error"
+	}
+	return rule, level
+}
+
+func (o Override) validate() error {
+	if len(o.Packages) == 0 && len(o.Files) == 0 {
+		return errors.New("packages or files is required")
+	}
+	for _, p := range o.Packages {
+		if _, err := path.Match(strings.TrimSuffix(p, "/..."), ""); err != nil {
+			return fmt.Errorf("invalid package pattern %q", p)
+		}
+	}
+	for _, f := range o.Files {
+		if _, err := path.Match(f, ""); err != nil {
+			return fmt.Errorf("invalid file glob %q", f)
+		}
+	}
+	return validateSeverity(o.Severity)
+}
+
+func validateSeverity(severity map[string]string) error {
+	for option, level := range severity {
+		if ruleByOption(option) == nil {
+			return fmt.Errorf("severity: unknown rule %q", option)
+		}
+		if !slices.Contains(severities, level) {
+			return fmt.Errorf("severity: rule %s: unknown level %q", option, level)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the override applies to a file of a package.
+func (o Override) matches(pkgPath, filename string) bool {
+	for _, p := range o.Packages {
+		if matchPackage(p, pkgPath) {
+			return true
+		}
+	}
+	filename = relativeName(o.dir, filename)
+	for _, f := range o.Files {
+		if matchGlob(f, filename) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPackage(pattern, pkgPath string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		if pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/") {
+			return true
+		}
+		pattern = prefix + "/*"
+	}
+	ok, _ := path.Match(pattern, pkgPath)
+	return ok
+}
+
+// matchGlob matches a slash-separated name against a glob where a ** element
+// matches any number of path elements.
+func matchGlob(pattern, name string) bool {
+	elem, rest, more := strings.Cut(pattern, "/")
+	if elem == "**" {
+		if !more || matchGlob(rest, name) {
+			return true
+		}
+		_, after, found := strings.Cut(name, "/")
+		return found && matchGlob(pattern, after)
+	}
+
+	nameElem, nameRest, nameMore := strings.Cut(name, "/")
+	if ok, _ := path.Match(elem, nameElem); !ok {
+		return false
+	}
+	if !more {
+		return !nameMore
+	}
+	return nameMore && matchGlob(rest, nameRest)
+}
+
+// apply returns cfg with the settings of the override.
+func (o Override) apply(cfg Config) Config {
+	cfg.Rules = cfg.Rules.merge(o.Rules)
+	if len(o.Keywords) > 0 {
+		cfg.Keywords = o.Keywords
+	}
+	cfg.Severity = mergeSeverity(cfg.Severity, o.Severity)
+	return cfg
+}
+
+// mergeSeverity returns the levels of severity with the ones in o replaced.
+func mergeSeverity(severity, o map[string]string) map[string]string {
+	if len(o) == 0 {
+		return severity
+	}
+	merged := maps.Clone(severity)
+	if merged == nil {
+		merged = make(map[string]string, len(o))
+	}
+	maps.Copy(merged, o)
+	return merged
+}
+
+// merge returns r with the rules set in o replaced.
+func (r RulesConfig) merge(o RulesConfig) RulesConfig {
+	dst := reflect.ValueOf(&r).Elem()
+	src := reflect.ValueOf(o)
+	for i := 0; i < src.NumField(); i++ {
+		if !src.Field(i).IsNil() {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+	return r
+}
+
+// fileConfig is the configuration of a file after applying the overrides.
+type fileConfig struct {
+	cfg Config
+	// key identifies the overrides applied, so files with the same
+	// configuration can share rule state.
+	key string
+	// excluded is set for files that no rule checks.
+	excluded bool
+}
+
+// fileConfigs returns the configuration of each file of the package that is
+// excluded, a test file or matched by an override, keyed by file name.
+// Test files have the rules that do not check tests disabled before
+// the overrides are applied, so an override can enable them again.
+func fileConfigs(pass *analysis.Pass, cfg Config) map[string]fileConfig {
+	files := make(map[string]fileConfig)
+	for _, f := range pass.Files {
+		name := pass.Fset.File(f.FileStart).Name()
+		if cfg.Exclude.excludes(f, name) {
+			files[name] = fileConfig{cfg: cfg, excluded: true}
+			continue
+		}
+
+		fc := fileConfig{cfg: cfg}
+		var applied []string
+		if cfg.Exclude.excludesTests() && isTestFile(name) {
+			fc.cfg.Rules = fc.cfg.Rules.merge(cfg.Exclude.testRules())
+			applied = append(applied, "tests")
+		}
+		for i, o := range cfg.Overrides {
+			if o.matches(pass.Pkg.Path(), name) {
+				fc.cfg = o.apply(fc.cfg)
+				applied = append(applied, strconv.Itoa(i))
+			}
+		}
+		if len(applied) > 0 {
+			fc.key = strings.Join(applied, ",")
+			files[name] = fc
+		}
+	}
+	return files
+}
+
+// relativeName returns filename relative to dir, with slashes, as file globs
+// in a config match it.
+func relativeName(dir, filename string) string {
+	if dir != "" {
+		if rel, err := filepath.Rel(dir, filename); err == nil {
+			filename = rel
+		}
+	}
+	return filepath.ToSlash(filename)
+}
+
+// configFor returns the configuration of the file containing pos.
+func (r *Result) configFor(pass *analysis.Pass, pos token.Pos) fileConfig {
+	if tf := pass.Fset.File(pos); tf != nil {
+		if fc, ok := r.files[tf.Name()]; ok {
+			return fc
+		}
+	}
+	return fileConfig{cfg: r.Config}
+}
@@ -0,0 +1,74 @@
+package loglint
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestMatchPackage(t *testing.T) {
+	tests := []struct {
+		pattern string
+		pkgPath string
+		want    bool
+	}{
+		{"example.com/app", "example.com/app", true},
+		{"example.com/app", "example.com/app/cmd", false},
+		{"example.com/app/...", "example.com/app", true},
+		{"example.com/app/...", "example.com/app/cmd/tool", true},
+		{"example.com/app/...", "example.com/application", false},
+		{"example.com/*/internal", "example.com/app/internal", true},
+		{"example.com/*/internal", "example.com/app/cmd/internal", false},
+	}
+	for _, tt := range tests {
+		if got := matchPackage(tt.pattern, tt.pkgPath); got != tt.want {
+			t.Errorf("matchPackage(%q, %q) = %v, want %v", tt.pattern, tt.pkgPath, got, tt.want)
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"main.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"cmd/*.go", "cmd/main.go", true},
+		{"cmd/**", "cmd/tool/main.go", true},
+		{"cmd/**", "internal/main.go", false},
+		{"**/*_gen.go", "models_gen.go", true},
+		{"**/*_gen.go", "internal/db/models_gen.go", true},
+		{"**/*_gen.go", "internal/db/models.go", false},
+		{"internal/**/testdata/*", "internal/a/b/testdata/x.go", true},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		category string
+		rule     string
+		level    string
+	}{
+		{"lowercase", "lowercase", "error"},
+		{"lowercase:warning", "lowercase", "warning"},
+		{"nospecial:info", "nospecial", "info"},
+		{"directives", "directives", "error"},
+		{"lowercase:fatal", "lowercase:fatal", "error"},
+	}
+	for _, tt := range tests {
+		d := analysis.Diagnostic{Category: tt.category}
+		if got := Rule(d); got != tt.rule {
+			t.Errorf("Rule(%q) = %q, want %q", tt.category, got, tt.rule)
+		}
+		if got := Severity(d); got != tt.level {
+			t.Errorf("Severity(%q) = %q, want %q", tt.category, got, tt.level)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package loglint
+
+import (
+	"go/ast"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// checkKeyValuePairs checks the alternating keys and values of slog calls.
+// slog reads the arguments the same way: a slog.Attr stands on its own,
+// a string is a key followed by its value, and anything else is logged
+// under the key !BADKEY.
+func checkKeyValuePairs(ctx *ruleContext, call *LogCall) {
+	if call.Func.Pkg().Path() != slogPkg {
+		return
+	}
+
+	info := ctx.pass.TypesInfo
+	args := call.KeyValues
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		t := info.TypeOf(arg)
+		switch {
+		case t == nil || isAttrType(t):
+		case !isString(t):
+			d := analysis.Diagnostic{
+				Pos:     arg.Pos(),
+				Message: "log key should be a string, not " + types.TypeString(t, types.RelativeTo(ctx.pass.Pkg)),
+			}
+			d.SuggestedFixes = insertKeyFix(info, arg)
+			ctx.report(d)
+		case i+1 == len(args):
+			if tv := info.Types[arg]; tv.Value != nil {
+				ctx.reportf(arg.Pos(), "log key %s has no value", tv.Value.ExactString())
+				break
+			}
+			// a trailing string variable is a value whose key was forgotten
+			ctx.report(analysis.Diagnostic{
+				Pos:            arg.Pos(),
+				Message:        "log value has no key",
+				SuggestedFixes: insertKeyFix(info, arg),
+			})
+		default:
+			if tv := info.Types[arg]; tv.Value == nil {
+				ctx.reportf(arg.Pos(), "log key should be a constant")
+			}
+			i++
+		}
+	}
+}
+
+// insertKeyFix returns a fix inserting a key named after value before it,
+// or nil if value is not a variable or field.
+func insertKeyFix(info *types.Info, value ast.Expr) []analysis.SuggestedFix {
+	name, ok := keyName(info, value)
+	if !ok {
+		return nil
+	}
+
+	key := strconv.Quote(name)
+	return []analysis.SuggestedFix{{
+		Message: "add key " + key,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     value.Pos(),
+			End:     value.Pos(),
+			NewText: []byte(key + ", "),
+		}},
+	}}
+}
+
+// keyName returns the name of the variable or field that value reads,
+// used as the key when a value is logged without one.
+func keyName(info *types.Info, value ast.Expr) (string, bool) {
+	var id *ast.Ident
+	switch v := ast.Unparen(value).(type) {
+	case *ast.Ident:
+		id = v
+	case *ast.SelectorExpr:
+		id = v.Sel
+	default:
+		return "", false
+	}
+	if _, ok := info.ObjectOf(id).(*types.Var); !ok {
+		return "", false
+	}
+	return id.Name, true
+}
@@ -0,0 +1,115 @@
+package loglint
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// checkRedaction reports logged values whose type has sensitive fields
+// but does not control its own log representation.
+func checkRedaction(ctx *ruleContext, call *LogCall) {
+	for _, a := range call.Attrs {
+		if a.Value != nil {
+			checkRedactedValue(ctx, a.Value)
+		}
+	}
+
+	if call.Format {
+		for _, arg := range formatArgs(ctx.pass.TypesInfo, call, 'v') {
+			checkRedactedValue(ctx, arg)
+		}
+	}
+}
+
+func checkRedactedValue(ctx *ruleContext, value ast.Expr) {
+	t := ctx.pass.TypesInfo.TypeOf(value)
+	if t == nil || hasLogRepresentation(t) {
+		return
+	}
+
+	field := sensitiveField(t, ctx.cfg.sensitiveKeywords(), make(map[types.Type]bool))
+	if field == "" {
+		return
+	}
+	ctx.reportf(value.Pos(), "log value of type %s should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field %s",
+		types.TypeString(t, types.RelativeTo(ctx.pass.Pkg)), field)
+}
+
+// hasLogRepresentation reports whether t or *t implements slog.LogValuer
+// or zapcore.ObjectMarshaler. The methods are matched by name and arity,
+// so the packages do not need to be imported.
+func hasLogRepresentation(t types.Type) bool {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	mset := types.NewMethodSet(types.NewPointer(t))
+	return hasMethod(mset, "LogValue", 0, 1) || hasMethod(mset, "MarshalLogObject", 1, 1)
+}
+
+func hasMethod(mset *types.MethodSet, name string, params, results int) bool {
+	for i := 0; i < mset.Len(); i++ {
+		fn, ok := mset.At(i).Obj().(*types.Func)
+		if !ok || fn.Name() != name {
+			continue
+		}
+		sig := fn.Type().(*types.Signature)
+		return sig.Params().Len() == params && sig.Results().Len() == results
+	}
+	return false
+}
+
+// sensitiveField returns the path of the first field reachable from t that is
+// tagged as sensitive or has a sensitive name, such as "Credentials.Password",
+// or "" if there is none. Named types already visited are skipped, so
+// recursive types terminate.
+func sensitiveField(t types.Type, keywords []string, seen map[types.Type]bool) string {
+	if named, ok := types.Unalias(t).(*types.Named); ok {
+		if seen[named] {
+			return ""
+		}
+		seen[named] = true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return sensitiveField(u.Elem(), keywords, seen)
+	case *types.Slice:
+		return sensitiveField(u.Elem(), keywords, seen)
+	case *types.Array:
+		return sensitiveField(u.Elem(), keywords, seen)
+	case *types.Map:
+		return sensitiveField(u.Elem(), keywords, seen)
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			f := u.Field(i)
+			if isSensitiveTag(u.Tag(i)) || containsSensitiveKeyword([]string{f.Name()}, keywords) {
+				return f.Name()
+			}
+			if path := sensitiveField(f.Type(), keywords, seen); path != "" {
+				return f.Name() + "." + path
+			}
+		}
+	}
+	return ""
+}
+
+// isSensitiveTag reports whether a struct tag marks the field as sensitive
+// with log:"redact" or sensitive:"true".
+func isSensitiveTag(tag string) bool {
+	st := reflect.StructTag(tag)
+	if opts, ok := st.Lookup("log"); ok {
+		for _, opt := range strings.Split(opts, ",") {
+			if opt == "redact" {
+				return true
+			}
+		}
+	}
+	if v, ok := st.Lookup("sensitive"); ok {
+		b, err := strconv.ParseBool(v)
+		return err == nil && b
+	}
+	return false
+}
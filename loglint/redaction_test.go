@@ -0,0 +1,23 @@
+package loglint
+
+import "testing"
+
+func TestIsSensitiveTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{`log:"redact"`, true},
+		{`json:"key" log:"redact,omitempty"`, true},
+		{`sensitive:"true"`, true},
+		{`sensitive:"false"`, false},
+		{`log:"name"`, false},
+		{`json:"password"`, false},
+		{``, false},
+	}
+	for _, tt := range tests {
+		if got := isSensitiveTag(tt.tag); got != tt.want {
+			t.Errorf("isSensitiveTag(%q) = %v, want %v", tt.tag, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package loglint
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RegistryKey is an approved structured log key.
+type RegistryKey struct {
+	Key string `yaml:"key"`
+	// Type is the expected Go type of the value, such as string or time.Duration.
+	// Types from other packages are qualified by package name or import path.
+	// Empty allows any type.
+	Type string `yaml:"type"`
+}
+
+// loadRegistry reads a key registry. A relative path is resolved against the
+// directory of the config file.
+func loadRegistry(configPath, path string) ([]RegistryKey, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(configPath), path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("key_registry: %w", err)
+	}
+
+	var keys []RegistryKey
+	if err := yaml.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("key_registry: %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for i, k := range keys {
+		if k.Key == "" {
+			return nil, fmt.Errorf("key_registry: %s: entry %d: key is required", path, i)
+		}
+		if seen[k.Key] {
+			return nil, fmt.Errorf("key_registry: %s: duplicate key %q", path, k.Key)
+		}
+		seen[k.Key] = true
+	}
+	return keys, nil
+}
+
+// checkKeyRegistry reports constant keys missing from the registry
+// and values whose type differs from the registered one.
+func checkKeyRegistry(ctx *ruleContext, call *LogCall) {
+	if ctx.cfg.registry == nil {
+		return
+	}
+
+	info := ctx.pass.TypesInfo
+	for _, a := range call.Attrs {
+		key, ok := constantString(info, a.Key)
+		if !ok {
+			continue
+		}
+
+		want, ok := ctx.cfg.registry[key]
+		if !ok {
+			ctx.reportf(a.Key.Pos(), "log key %q is not in the key registry", key)
+			continue
+		}
+		if want == "" || a.Value == nil {
+			continue
+		}
+
+		t := info.TypeOf(a.Value)
+		if t == nil || types.IsInterface(t) || typeMatches(t, want) {
+			continue
+		}
+		ctx.reportf(a.Value.Pos(), "log key %q should have a value of type %s, not %s",
+			key, want, types.TypeString(t, (*types.Package).Name))
+	}
+}
+
+// typeMatches reports whether t is written as name, with other packages
+// qualified either by package name or by import path.
+func typeMatches(t types.Type, name string) bool {
+	return types.TypeString(t, (*types.Package).Name) == name ||
+		types.TypeString(t, (*types.Package).Path) == name
+}
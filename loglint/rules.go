@@ -1,8 +1,10 @@
 package loglint
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"strconv"
 	"strings"
 	"unicode"
@@ -11,6 +13,424 @@ import (
 	"golang.org/x/tools/go/analysis"
 )
 
+// rule is a single check applied to the log calls found by the Detector.
+type rule struct {
+	name    string
+	option  string // the key of the rule in the rules and severity sections of the config
+	doc     string
+	enabled func(Config) bool
+	check   func(ctx *ruleContext, call *LogCall)
+}
+
+// ruleContext is passed to rule checks while they run on a package.
+type ruleContext struct {
+	pass   *analysis.Pass
+	result *Result
+	cfg    Config
+	rule   *rule
+	checks *checks
+
+	taint  *taintAnalysis
+	consts map[*types.Const]*ast.BasicLit
+}
+
+// report records a diagnostic categorized by the rule name
+// unless it is ignored by a directive.
+func (c *ruleContext) report(d analysis.Diagnostic) {
+	level := c.cfg.severity(c.rule)
+	d.Category = category(c.rule.name, level)
+	if level != "error" {
+		d.Message = level + ": " + d.Message
+	}
+	if c.checks.suppressor.suppresses(c.rule.name, d.Pos) {
+		return
+	}
+	c.checks.diagnostics = append(c.checks.diagnostics, d)
+}
+
+func (c *ruleContext) reportf(pos token.Pos, format string, args ...interface{}) {
+	c.report(analysis.Diagnostic{Pos: pos, Message: fmt.Sprintf(format, args...)})
+}
+
+// specialChars returns the compiled special characters configuration.
+func (c *ruleContext) specialChars() specialChars {
+	return c.cfg.chars
+}
+
+// messagePart is a constant string in a log message.
+type messagePart struct {
+	value string
+	// lit is the literal that fixes of the part edit: the part itself or,
+	// for a constant declared in the package, the literal in its declaration.
+	// It is nil for other constants.
+	lit *ast.BasicLit
+}
+
+// messageParts returns the constant parts of a message concatenation in order.
+// Named constants and constant expressions are evaluated, so constants from
+// other packages are checked as well.
+func (c *ruleContext) messageParts(expr ast.Expr) []messagePart {
+	var parts []messagePart
+	var walk func(ast.Expr)
+	walk = func(e ast.Expr) {
+		switch node := ast.Unparen(e).(type) {
+		case *ast.BasicLit:
+			if val, err := strconv.Unquote(node.Value); node.Kind == token.STRING && err == nil {
+				parts = append(parts, messagePart{value: val, lit: node})
+			}
+			return
+		case *ast.BinaryExpr:
+			if node.Op == token.ADD {
+				walk(node.X)
+				walk(node.Y)
+				return
+			}
+		}
+		if val, ok := constantString(c.pass.TypesInfo, e); ok {
+			parts = append(parts, messagePart{value: val, lit: c.constLit(e)})
+		}
+	}
+	walk(expr)
+	return parts
+}
+
+// constLit returns the literal a constant of the package is declared with,
+// or nil if expr is not such a constant. Conversions such as string(msg) are
+// looked through.
+func (c *ruleContext) constLit(expr ast.Expr) *ast.BasicLit {
+	var id *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	case *ast.CallExpr:
+		if tv, ok := c.pass.TypesInfo.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return c.constLit(e.Args[0])
+		}
+		return nil
+	default:
+		return nil
+	}
+	obj, ok := c.pass.TypesInfo.Uses[id].(*types.Const)
+	if !ok || obj.Pkg() != c.pass.Pkg {
+		return nil
+	}
+
+	if c.consts == nil {
+		c.consts = constLits(c.pass)
+	}
+	return c.consts[obj]
+}
+
+// constLits maps the constants of the package declared with a string literal to the literal.
+func constLits(pass *analysis.Pass) map[*types.Const]*ast.BasicLit {
+	consts := make(map[*types.Const]*ast.BasicLit)
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, name := range spec.Names {
+				if i >= len(spec.Values) {
+					break
+				}
+				lit, ok := ast.Unparen(spec.Values[i]).(*ast.BasicLit)
+				if obj, isConst := pass.TypesInfo.Defs[name].(*types.Const); isConst && ok && lit.Kind == token.STRING {
+					consts[obj] = lit
+				}
+			}
+			return false
+		})
+	}
+	return consts
+}
+
+func isConcatenation(expr ast.Expr) bool {
+	b, ok := ast.Unparen(expr).(*ast.BinaryExpr)
+	return ok && b.Op == token.ADD
+}
+
+// taintAnalysis returns the taint analysis of the package, running it on first use.
+func (c *ruleContext) taintAnalysis() *taintAnalysis {
+	if c.taint == nil {
+		c.taint = newTaintAnalysis(c.pass, c.cfg, c.result.srcFuncs(c.pass))
+	}
+	return c.taint
+}
+
+// keyStyle returns the compiled key style of the configuration.
+func (c *ruleContext) keyStyle() *keyStyle {
+	return c.cfg.style
+}
+
+var rules = []*rule{
+	lowercaseRule,
+	englishOnlyRule,
+	noSpecialRule,
+	sensitiveDataRule,
+	sensitiveFlowRule,
+	redactionRule,
+	printfRule,
+	formatVerbsRule,
+	constantMessageRule,
+	keyValueRule,
+	keyStyleRule,
+	duplicateKeysRule,
+	keyRegistryRule,
+	zerologSendRule,
+}
+
+var lowercaseRule = &rule{
+	name:    "lowercase",
+	option:  "lowercase",
+	doc:     "checks that log messages start with a lowercase letter",
+	enabled: Config.isLowercaseEnabled,
+	check:   checkLowercase,
+}
+
+var englishOnlyRule = &rule{
+	name:    "englishonly",
+	option:  "english_only",
+	doc:     "checks that log messages are in English only",
+	enabled: Config.isEnglishOnlyEnabled,
+	check:   checkEnglishOnly,
+}
+
+var noSpecialRule = &rule{
+	name:    "nospecial",
+	option:  "no_special_chars",
+	doc:     "checks that log messages do not contain special characters or emoji",
+	enabled: Config.isNoSpecialEnabled,
+	check:   checkNoSpecial,
+}
+
+var sensitiveDataRule = &rule{
+	name:    "sensitivedata",
+	option:  "sensitive_data",
+	doc:     "checks that log messages and fields do not contain sensitive data",
+	enabled: Config.isSensitiveDataEnabled,
+	check:   checkSensitive,
+}
+
+var sensitiveFlowRule = &rule{
+	name:    "sensitiveflow",
+	option:  "sensitive_flow",
+	doc:     "checks that values derived from sensitive variables, fields and calls do not reach log calls",
+	enabled: Config.isSensitiveFlowEnabled,
+	check:   checkSensitiveFlow,
+}
+
+var redactionRule = &rule{
+	name:    "redaction",
+	option:  "redaction",
+	doc:     "checks that logged values with sensitive fields implement slog.LogValuer or zapcore.ObjectMarshaler",
+	enabled: Config.isRedactionEnabled,
+	check:   checkRedaction,
+}
+
+var printfRule = &rule{
+	name:    "printf",
+	option:  "printf",
+	doc:     "checks that printf-style log formats match their arguments",
+	enabled: Config.isPrintfEnabled,
+	check:   checkPrintf,
+}
+
+var formatVerbsRule = &rule{
+	name:    "formatverbs",
+	option:  "format_verbs",
+	doc:     "checks that slog and zap methods without formatting are not passed printf-style verbs",
+	enabled: Config.isFormatVerbsEnabled,
+	check:   checkFormatVerbs,
+}
+
+var constantMessageRule = &rule{
+	name:    "constantmessage",
+	option:  "constant_message",
+	doc:     "checks that log messages are constant and not built with fmt.Sprintf, concatenation or strings.Join",
+	enabled: Config.isConstantMessageEnabled,
+	check:   checkConstantMessage,
+}
+
+var keyValueRule = &rule{
+	name:    "keyvalue",
+	option:  "key_value",
+	doc:     "checks that slog key-value arguments come in pairs with constant string keys",
+	enabled: Config.isKeyValueEnabled,
+	check:   checkKeyValuePairs,
+}
+
+var keyStyleRule = &rule{
+	name:    "keystyle",
+	option:  "key_style",
+	doc:     "checks that structured log keys follow the configured naming convention",
+	enabled: Config.isKeyStyleEnabled,
+	check:   checkKeyStyle,
+}
+
+var duplicateKeysRule = &rule{
+	name:    "duplicatekeys",
+	option:  "duplicate_keys",
+	doc:     "checks that a log call and its With chain do not set the same key twice",
+	enabled: Config.isDuplicateKeysEnabled,
+	check:   checkDuplicateKeys,
+}
+
+var keyRegistryRule = &rule{
+	name:    "keyregistry",
+	option:  "key_registry",
+	doc:     "checks structured log keys and value types against the key registry",
+	enabled: Config.isKeyRegistryEnabled,
+	check:   checkKeyRegistry,
+}
+
+var zerologSendRule = &rule{
+	name:    "zerologsend",
+	option:  "zerolog_send",
+	doc:     "checks that zerolog events are finished with a message",
+	enabled: Config.isZerologSendEnabled,
+	check: func(ctx *ruleContext, call *LogCall) {
+		if call.Kind == KindSend {
+			checkZerologSend(ctx, call.Call)
+		}
+	},
+}
+
+func checkLowercase(ctx *ruleContext, call *LogCall) {
+	if call.Kind != KindMessage {
+		return
+	}
+
+	parts := ctx.messageParts(call.Message)
+	if len(parts) == 0 || !ctx.cfg.needsLowercase(messageText(call.Format, parts[0].value)) {
+		return
+	}
+
+	d := analysis.Diagnostic{
+		Pos:     call.Message.Pos(),
+		Message: "log message should start with a lowercase letter",
+	}
+	if ctx.lowercaseFixes(call, parts) {
+		fixed := toLowercaseStart(parts[0].value)
+		if chars := ctx.specialChars(); ctx.cfg.isNoSpecialEnabled() && chars.hasSpecialChars(messageText(call.Format, fixed)) {
+			fixed = removeSpecialChars(chars, call.Format, fixed)
+		}
+		d.SuggestedFixes = suggestedFix("fix log message", parts[0].lit, fixed)
+	}
+	ctx.report(d)
+}
+
+func checkEnglishOnly(ctx *ruleContext, call *LogCall) {
+	if call.Kind != KindMessage {
+		return
+	}
+
+	for _, part := range ctx.messageParts(call.Message) {
+		if hasNonEnglish(messageText(call.Format, part.value)) {
+			ctx.reportf(call.Message.Pos(), "log message should be in English only")
+			return
+		}
+	}
+}
+
+func checkNoSpecial(ctx *ruleContext, call *LogCall) {
+	if call.Kind != KindMessage {
+		return
+	}
+
+	format := call.Format || hasMisplacedVerbs(ctx, call)
+	chars := ctx.specialChars()
+	parts := ctx.messageParts(call.Message)
+	for i, part := range parts {
+		if !chars.hasSpecialChars(messageText(format, part.value)) {
+			continue
+		}
+
+		d := analysis.Diagnostic{
+			Pos:     call.Message.Pos(),
+			Message: "log message should not contain special characters or emoji",
+		}
+
+		// the lowercase fix already strips special characters from the first literal
+		needsFix := !(i == 0 && ctx.cfg.isLowercaseEnabled() && ctx.lowercaseFixes(call, parts))
+
+		// a %% escape is kept, so a format may have nothing left to remove
+		if fixed := removeSpecialChars(chars, format, part.value); needsFix && part.lit != nil && fixed != part.value {
+			d.SuggestedFixes = suggestedFix("remove special characters", part.lit, fixed)
+		}
+		ctx.report(d)
+		return
+	}
+}
+
+func checkSensitive(ctx *ruleContext, call *LogCall) {
+	if call.Kind == KindMessage {
+		checkSensitiveData(ctx, call.Message)
+	}
+	for _, a := range call.Attrs {
+		if a.Value != nil {
+			checkSensitiveKey(ctx, a.Key, a.Value)
+		}
+	}
+}
+
+// messageText returns the text of a message literal, without the verbs
+// if the message is a printf-style format.
+func messageText(format bool, val string) string {
+	if format {
+		return stripFormatVerbs(val)
+	}
+	return val
+}
+
+// removeSpecialChars strips special characters from a message literal,
+// keeping the verbs if the message is a printf-style format.
+func removeSpecialChars(chars specialChars, format bool, val string) string {
+	if format {
+		return mapFormatText(val, chars.stripSpecialChars)
+	}
+	return chars.stripSpecialChars(val)
+}
+
+// hasMisplacedVerbs reports whether the formatverbs rule reports the verbs
+// in the message, so other rules do not report them as special characters.
+func hasMisplacedVerbs(ctx *ruleContext, call *LogCall) bool {
+	if !ctx.cfg.isFormatVerbsEnabled() {
+		return false
+	}
+	_, verbs := misplacedVerbs(ctx.pass.TypesInfo, call)
+	return len(verbs) > 0
+}
+
+// lowercaseFixes reports whether the lowercase rule fixes the first part of the message.
+// A message starting with an initialism such as an unknown acronym or HTTPServer is
+// reported but not fixed, since lowercasing its first letter would only make it worse.
+func (c *ruleContext) lowercaseFixes(call *LogCall, parts []messagePart) bool {
+	first := parts[0]
+	return first.lit != nil && !isConcatenation(call.Message) &&
+		c.cfg.needsLowercase(first.value) && !isInitialism(firstWord(first.value))
+}
+
+// firstWord returns the letters and digits the message starts with.
+func firstWord(msg string) string {
+	for i, r := range msg {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return msg[:i]
+		}
+	}
+	return msg
+}
+
+// isInitialism reports whether word starts with an initialism such as HTTP, S3,
+// URLs or the HTTP of HTTPServer: a capital followed by a capital or a digit.
+func isInitialism(word string) bool {
+	r, size := utf8.DecodeRuneInString(word)
+	next, _ := utf8.DecodeRuneInString(word[size:])
+	return unicode.IsUpper(r) && (unicode.IsUpper(next) || unicode.IsDigit(next))
+}
+
 // isUppercaseStart returns true if the message starts with an uppercase letter.
 func isUppercaseStart(msg string) bool {
 	if len(msg) == 0 {
@@ -20,7 +440,6 @@ func isUppercaseStart(msg string) bool {
 	return unicode.IsUpper(r)
 }
 
-
 // hasNonEnglish returns true if the message contains non-ASCII letters.
 func hasNonEnglish(msg string) bool {
 	for _, r := range msg {
@@ -31,18 +450,6 @@ func hasNonEnglish(msg string) bool {
 	return false
 }
 
-// hasSpecialChars returns true if the message contains special characters or emoji.
-// Allowed characters: letters, digits and spaces.
-func hasSpecialChars(msg string) bool {
-	for _, r := range msg {
-		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' {
-			return true
-		}
-	}
-	return false
-}
-
-
 // containsSensitiveKeyword returns true if any of the values contains a sensitive keyword.
 func containsSensitiveKeyword(values []string, keywords []string) bool {
 	for _, val := range values {
@@ -68,20 +475,43 @@ func litValues(lits []*ast.BasicLit) []string {
 	return result
 }
 
-
-func checkSensitiveData(pass *analysis.Pass, expr ast.Expr, keywords []string) {
-	binExpr, ok := expr.(*ast.BinaryExpr)
+// checkSensitiveData reports a message concatenating a value to constant text
+// containing a sensitive keyword. Named constants and constant expressions in
+// the concatenation are evaluated.
+func checkSensitiveData(ctx *ruleContext, expr ast.Expr) {
+	binExpr, ok := ast.Unparen(expr).(*ast.BinaryExpr)
 	if !ok || binExpr.Op != token.ADD {
 		return
 	}
 
-	if !hasNonLiteralParts(expr) {
+	// a constant message holds no data
+	if _, ok := constantString(ctx.pass.TypesInfo, expr); ok {
+		return
+	}
+
+	parts := ctx.messageParts(expr)
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		values = append(values, part.value)
+	}
+	if containsSensitiveKeyword(values, ctx.cfg.sensitiveKeywords()) {
+		ctx.reportf(expr.Pos(), "log message should not contain sensitive data")
+	}
+}
+
+// checkSensitiveKey reports a literal key containing a sensitive keyword when its value is not a constant.
+func checkSensitiveKey(ctx *ruleContext, key, value ast.Expr) {
+	lit, ok := key.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return
+	}
+
+	if tv, ok := ctx.pass.TypesInfo.Types[value]; ok && tv.Value != nil {
 		return
 	}
 
-	lits := collectLits(expr)
-	if containsSensitiveKeyword(litValues(lits), keywords) {
-		pass.Reportf(expr.Pos(), "log message should not contain sensitive data")
+	if containsSensitiveKeyword(litValues([]*ast.BasicLit{lit}), ctx.cfg.sensitiveKeywords()) {
+		ctx.reportf(key.Pos(), "log field should not contain sensitive data")
 	}
 }
 
@@ -98,28 +528,6 @@ func hasNonLiteralParts(expr ast.Expr) bool {
 	return true
 }
 
-// collectLits returns all string BasicLit nodes from the expression tree.
-func collectLits(expr ast.Expr) []*ast.BasicLit {
-	var lits []*ast.BasicLit
-	var walk func(ast.Expr)
-	walk = func(e ast.Expr) {
-		switch node := e.(type) {
-		case *ast.BasicLit:
-			if node.Kind == token.STRING {
-				lits = append(lits, node)
-			}
-		case *ast.BinaryExpr:
-			if node.Op == token.ADD {
-				walk(node.X)
-				walk(node.Y)
-			}
-		}
-	}
-	walk(expr)
-	return lits
-}
-
-
 // toLowercaseStart returns the message with the first letter lowercased.
 func toLowercaseStart(msg string) string {
 	if len(msg) == 0 {
@@ -129,17 +537,6 @@ func toLowercaseStart(msg string) string {
 	return string(unicode.ToLower(r)) + msg[size:]
 }
 
-// stripSpecialChars removes all characters that are not letters, digits or spaces.
-func stripSpecialChars(msg string) string {
-	var b strings.Builder
-	for _, r := range msg {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' {
-			b.WriteRune(r)
-		}
-	}
-	return b.String()
-}
-
 // suggestedFix creates a SuggestedFix that replaces a BasicLit with newText.
 func suggestedFix(message string, lit *ast.BasicLit, newText string) []analysis.SuggestedFix {
 	return []analysis.SuggestedFix{{
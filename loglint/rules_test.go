@@ -3,6 +3,7 @@ package loglint
 import (
 	"go/ast"
 	"go/parser"
+	"go/token"
 	"testing"
 )
 
@@ -79,7 +80,7 @@ func TestStripSpecialChars(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := stripSpecialChars(tt.msg)
+			got := specialChars{}.stripSpecialChars(tt.msg)
 			if got != tt.want {
 				t.Errorf("stripSpecialChars(%q) = %q, want %q", tt.msg, got, tt.want)
 			}
@@ -131,7 +132,7 @@ func TestHasSpecialChars(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := hasSpecialChars(tt.msg)
+			got := specialChars{}.hasSpecialChars(tt.msg)
 			if got != tt.want {
 				t.Errorf("hasSpecialChars(%q) = %v, want %v", tt.msg, got, tt.want)
 			}
@@ -169,38 +170,15 @@ func TestContainsSensitiveKeyword(t *testing.T) {
 	}
 }
 
-func TestCollectLits(t *testing.T) {
-	tests := []struct {
-		name       string
-		src        string
-		wantCount  int
-		wantValues []string
-	}{
-		{"single literal", `"hello"`, 1, []string{"hello"}},
-		{"concat two literals", `"hello" + "world"`, 2, []string{"hello", "world"}},
-		{"concat with ident", `"hello" + x`, 1, []string{"hello"}},
-		{"identifier only", `x`, 0, nil},
-		{"triple concat", `"a" + x + "b"`, 2, []string{"a", "b"}},
+func TestLitValues(t *testing.T) {
+	lits := []*ast.BasicLit{
+		{Kind: token.STRING, Value: `"hello"`},
+		{Kind: token.STRING, Value: "`world`"},
+		{Kind: token.STRING, Value: `"unterminated`},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			expr := mustParseExpr(t, tt.src)
-			lits := collectLits(expr)
-			if len(lits) != tt.wantCount {
-				t.Errorf("collectLits(%q) returned %d literals, want %d", tt.src, len(lits), tt.wantCount)
-			}
-			if tt.wantValues != nil {
-				values := litValues(lits)
-				if len(values) != len(tt.wantValues) {
-					t.Errorf("litValues returned %d values, want %d", len(values), len(tt.wantValues))
-				}
-				for i, v := range values {
-					if i < len(tt.wantValues) && v != tt.wantValues[i] {
-						t.Errorf("litValues[%d] = %q, want %q", i, v, tt.wantValues[i])
-					}
-				}
-			}
-		})
+	values := litValues(lits)
+	if len(values) != 2 || values[0] != "hello" || values[1] != "world" {
+		t.Errorf("litValues = %q, want [hello world]", values)
 	}
 }
 
@@ -225,3 +203,49 @@ func TestHasNonLiteralParts(t *testing.T) {
 		})
 	}
 }
+
+func TestIsInitialism(t *testing.T) {
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"HTTP", true},
+		{"S3", true},
+		{"URLs", true},
+		{"HTTPServer", true},
+		{"OAuth", true},
+		{"A", false},
+		{"Kafka", false},
+		{"", false},
+		{"42", false},
+	}
+	for _, tt := range tests {
+		if got := isInitialism(tt.word); got != tt.want {
+			t.Errorf("isInitialism(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestNeedsLowercase(t *testing.T) {
+	cfg := Config{LowercaseExceptions: []string{"Acme"}}
+	tests := []struct {
+		msg  string
+		want bool
+	}{
+		{"HTTP server started", false},
+		{"ID mismatch", false},
+		{"IDs reloaded", false},
+		{"JWT expired", false},
+		{"Kafka consumer ready", false},
+		{"Acme client ready", false},
+		{"Starting server", true},
+		{"FTP server started", true},
+		{"Kafkaesque delay", true},
+		{"starting server", false},
+	}
+	for _, tt := range tests {
+		if got := cfg.needsLowercase(tt.msg); got != tt.want {
+			t.Errorf("needsLowercase(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
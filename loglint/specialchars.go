@@ -0,0 +1,121 @@
+package loglint
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SpecialCharsConfig configures the characters the no_special_chars rule allows
+// besides letters, digits and spaces.
+type SpecialCharsConfig struct {
+	// Allowed lists single characters and the character classes hyphen,
+	// underscore, parentheses, slash, colon and inner_dot, a dot between
+	// letters or digits as in v1.2 or example.com.
+	Allowed []string `yaml:"allowed"`
+	// AllowEmoji allows emoji. It is disabled by default.
+	AllowEmoji *bool `yaml:"allow_emoji"`
+}
+
+// merge returns s with the settings of a nested config replaced.
+func (s SpecialCharsConfig) merge(n SpecialCharsConfig) SpecialCharsConfig {
+	if n.Allowed != nil {
+		s.Allowed = n.Allowed
+	}
+	if n.AllowEmoji != nil {
+		s.AllowEmoji = n.AllowEmoji
+	}
+	return s
+}
+
+func (s SpecialCharsConfig) allowsEmoji() bool {
+	return s.AllowEmoji != nil && *s.AllowEmoji
+}
+
+var charClasses = map[string]string{
+	"hyphen":      "-",
+	"underscore":  "_",
+	"parentheses": "()",
+	"slash":       "/",
+	"colon":       ":",
+}
+
+// specialChars is a compiled SpecialCharsConfig.
+// The zero value allows only letters, digits and spaces.
+type specialChars struct {
+	allowed  map[rune]bool
+	innerDot bool
+	emoji    bool
+}
+
+func newSpecialChars(cfg SpecialCharsConfig) (specialChars, error) {
+	s := specialChars{allowed: make(map[rune]bool), emoji: cfg.allowsEmoji()}
+	for _, name := range cfg.Allowed {
+		if chars, ok := charClasses[name]; ok {
+			for _, r := range chars {
+				s.allowed[r] = true
+			}
+			continue
+		}
+		if name == "inner_dot" {
+			s.innerDot = true
+			continue
+		}
+		if utf8.RuneCountInString(name) != 1 {
+			return specialChars{}, fmt.Errorf("special_chars: unknown character class %q", name)
+		}
+		r, _ := utf8.DecodeRuneInString(name)
+		s.allowed[r] = true
+	}
+	return s, nil
+}
+
+// hasSpecialChars returns true if the message contains special characters or emoji
+// that are not allowed.
+func (s specialChars) hasSpecialChars(msg string) bool {
+	return s.stripSpecialChars(msg) != msg
+}
+
+// stripSpecialChars removes all characters that are not letters, digits,
+// spaces or allowed characters.
+func (s specialChars) stripSpecialChars(msg string) string {
+	var b strings.Builder
+	prev := utf8.RuneError
+	for i := 0; i < len(msg); {
+		r, size := utf8.DecodeRuneInString(msg[i:])
+		i += size
+		next, _ := utf8.DecodeRuneInString(msg[i:])
+		if s.isAllowed(prev, r, next) {
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+	return b.String()
+}
+
+func (s specialChars) isAllowed(prev, r, next rune) bool {
+	switch {
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ':
+		return true
+	case s.allowed[r]:
+		return true
+	case r == '.' && s.innerDot:
+		return isWordRune(prev) && isWordRune(next)
+	case s.emoji:
+		return isEmoji(r)
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isEmoji reports whether r is an emoji symbol or a rune joining or modifying one.
+func isEmoji(r rune) bool {
+	return unicode.Is(unicode.So, r) ||
+		r == '\u200d' || // zero width joiner
+		(r >= '\ufe00' && r <= '\ufe0f') || // variation selectors
+		(r >= 0x1f3fb && r <= 0x1f3ff) // skin tone modifiers
+}
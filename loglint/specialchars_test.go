@@ -0,0 +1,57 @@
+package loglint
+
+import "testing"
+
+func TestSpecialCharsAllowed(t *testing.T) {
+	cfg := SpecialCharsConfig{Allowed: []string{"hyphen", "underscore", "parentheses", "slash", "colon", "inner_dot", "="}}
+	chars, err := newSpecialChars(cfg)
+	if err != nil {
+		t.Fatalf("newSpecialChars: %v", err)
+	}
+
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"retrying request (attempt limit reached)", "retrying request (attempt limit reached)"},
+		{"cache hit-rate low", "cache hit-rate low"},
+		{"user_id mismatch", "user_id mismatch"},
+		{"warning: read /etc/hosts", "warning: read /etc/hosts"},
+		{"upgraded to v1.2 from example.com", "upgraded to v1.2 from example.com"},
+		{"loading...", "loading"},
+		{"server started.", "server started"},
+		{"limit=10!", "limit=10"},
+		{"done \U0001F680", "done "},
+	}
+	for _, tt := range tests {
+		if got := chars.stripSpecialChars(tt.msg); got != tt.want {
+			t.Errorf("stripSpecialChars(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+		if got := chars.hasSpecialChars(tt.msg); got != (tt.msg != tt.want) {
+			t.Errorf("hasSpecialChars(%q) = %v, want %v", tt.msg, got, !got)
+		}
+	}
+}
+
+func TestSpecialCharsEmoji(t *testing.T) {
+	allow := true
+	chars, err := newSpecialChars(SpecialCharsConfig{AllowEmoji: &allow})
+	if err != nil {
+		t.Fatalf("newSpecialChars: %v", err)
+	}
+
+	for _, msg := range []string{"done \U0001F680", "ok ✅", "deployed \U0001F44D\U0001F3FD", "love ❤️"} {
+		if chars.hasSpecialChars(msg) {
+			t.Errorf("hasSpecialChars(%q) = true, want false", msg)
+		}
+	}
+	if got := chars.stripSpecialChars("done! \U0001F680"); got != "done \U0001F680" {
+		t.Errorf("stripSpecialChars kept %q", got)
+	}
+}
+
+func TestSpecialCharsUnknownClass(t *testing.T) {
+	if _, err := newSpecialChars(SpecialCharsConfig{Allowed: []string{"dashes"}}); err == nil {
+		t.Error("expected error for unknown character class")
+	}
+}
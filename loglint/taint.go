@@ -0,0 +1,410 @@
+package loglint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// taintPackages are the non-logger packages whose functions pass the taint
+// of their arguments on to their results, such as fmt.Sprintf or strings.Join.
+var taintPackages = map[string]bool{
+	"bytes":   true,
+	"errors":  true,
+	"fmt":     true,
+	"strconv": true,
+	"strings": true,
+}
+
+// taintAnalysis tracks the SSA values of a package that are derived from
+// sensitive parameters, variables, struct fields and function results.
+//
+// The analysis is intraprocedural and flow-insensitive: a value is tainted
+// if it is computed from a tainted value anywhere in the same function.
+type taintAnalysis struct {
+	keywords []string
+	// packages are the packages whose calls propagate taint: logger
+	// packages (attribute constructors, With) and taintPackages.
+	packages map[string]bool
+	// sources maps a tainted value to the name of its sensitive source.
+	sources map[ssa.Value]string
+	// calls maps the Lparen of a call to its SSA form.
+	calls map[token.Pos]*ssa.CallCommon
+}
+
+func newTaintAnalysis(pass *analysis.Pass, cfg Config, funcs []*ssa.Function) *taintAnalysis {
+	t := &taintAnalysis{
+		keywords: cfg.sensitiveKeywords(),
+		packages: make(map[string]bool),
+		sources:  make(map[ssa.Value]string),
+		calls:    make(map[token.Pos]*ssa.CallCommon),
+	}
+	for path := range taintPackages {
+		t.packages[path] = true
+	}
+	for _, l := range cfg.loggers() {
+		t.packages[l.Package] = true
+	}
+
+	named := t.sensitiveAssignments(pass.Files)
+	for _, fn := range funcs {
+		t.analyze(fn, named)
+	}
+	return t
+}
+
+// srcFuncs returns the SSA source functions of the package, building them
+// the first time. Rule contexts and analyzers of the package share them.
+func (r *Result) srcFuncs(pass *analysis.Pass) []*ssa.Function {
+	r.ssaOnce.Do(func() {
+		r.ssaFuncs = buildSSA(pass)
+	})
+	return r.ssaFuncs
+}
+
+// buildSSA builds the SSA form of the package and returns its source functions,
+// including function literals, as buildssa.Analyzer does. The rule builds it
+// itself, so packages are not converted to SSA unless the rule is enabled.
+func buildSSA(pass *analysis.Pass) []*ssa.Function {
+	prog := ssa.NewProgram(pass.Fset, ssa.BuilderMode(0))
+	for _, p := range pass.Pkg.Imports() {
+		prog.CreatePackage(p, nil, nil, true)
+	}
+	pkg := prog.CreatePackage(pass.Pkg, pass.Files, pass.TypesInfo, false)
+	pkg.Build()
+
+	var funcs []*ssa.Function
+	var add func(fn *ssa.Function)
+	add = func(fn *ssa.Function) {
+		funcs = append(funcs, fn)
+		for _, anon := range fn.AnonFuncs {
+			add(anon)
+		}
+	}
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok {
+				if fn := prog.FuncValue(pass.TypesInfo.Defs[decl.Name].(*types.Func)); fn != nil {
+					add(fn)
+				}
+			}
+		}
+	}
+	return funcs
+}
+
+func (t *taintAnalysis) isSensitive(name string) bool {
+	return name != "" && containsSensitiveKeyword([]string{name}, t.keywords)
+}
+
+// sensitiveAssignments maps the SSA position of values assigned to local
+// variables with sensitive names to the variable name. SSA built without
+// debug information does not keep the names of locals, so the assignment
+// is matched by position instead.
+func (t *taintAnalysis) sensitiveAssignments(files []*ast.File) map[token.Pos]string {
+	named := make(map[token.Pos]string)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			var lhs, rhs []ast.Expr
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				lhs, rhs = n.Lhs, n.Rhs
+			case *ast.ValueSpec:
+				for _, name := range n.Names {
+					lhs = append(lhs, name)
+				}
+				rhs = n.Values
+			}
+			if len(lhs) != len(rhs) {
+				return true
+			}
+			for i, e := range lhs {
+				id, ok := e.(*ast.Ident)
+				if !ok || !t.isSensitive(id.Name) {
+					continue
+				}
+				if pos := ssaPos(rhs[i]); pos.IsValid() {
+					named[pos] = id.Name
+				}
+			}
+			return true
+		})
+	}
+	return named
+}
+
+// ssaPos returns the position the SSA builder gives to the value of expr,
+// or token.NoPos if expr does not produce an instruction of its own.
+func ssaPos(expr ast.Expr) token.Pos {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		return e.Lparen
+	case *ast.BinaryExpr:
+		return e.OpPos
+	case *ast.UnaryExpr:
+		return e.OpPos
+	case *ast.IndexExpr:
+		return e.Lbrack
+	case *ast.SliceExpr:
+		return e.Lbrack
+	case *ast.TypeAssertExpr:
+		return e.Lparen
+	case *ast.SelectorExpr:
+		// field and package-level variable loads
+		return e.Sel.Pos()
+	}
+	return token.NoPos
+}
+
+// analyze finds the sensitive sources of fn and propagates their taint.
+func (t *taintAnalysis) analyze(fn *ssa.Function, named map[token.Pos]string) {
+	var queue []ssa.Value
+	mark := func(v ssa.Value, name string) {
+		if _, ok := t.sources[v]; ok {
+			return
+		}
+		t.sources[v] = name
+		queue = append(queue, v)
+	}
+
+	for _, p := range fn.Params {
+		if t.isSensitive(p.Name()) {
+			mark(p, p.Name())
+		}
+	}
+	for _, fv := range fn.FreeVars {
+		if t.isSensitive(fv.Name()) {
+			mark(fv, fv.Name())
+		}
+	}
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if c, ok := instr.(ssa.CallInstruction); ok {
+				t.calls[c.Common().Pos()] = c.Common()
+			}
+			v, ok := instr.(ssa.Value)
+			if !ok {
+				continue
+			}
+			if name, ok := named[v.Pos()]; ok && v.Pos().IsValid() {
+				mark(v, name)
+			}
+			if name := sourceName(v); t.isSensitive(name) {
+				mark(v, name)
+			}
+		}
+	}
+
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		refs := v.Referrers()
+		if refs == nil {
+			continue
+		}
+		for _, ref := range *refs {
+			t.propagate(ref, v, mark)
+		}
+	}
+}
+
+// sourceName returns the name that makes v a potential sensitive source:
+// the selected struct field, the called function, the loaded global
+// or the variable a local is allocated for.
+func sourceName(v ssa.Value) string {
+	switch v := v.(type) {
+	case *ssa.Field:
+		if s, ok := v.X.Type().Underlying().(*types.Struct); ok {
+			return s.Field(v.Field).Name()
+		}
+	case *ssa.FieldAddr:
+		if p, ok := v.X.Type().Underlying().(*types.Pointer); ok {
+			if s, ok := p.Elem().Underlying().(*types.Struct); ok {
+				return s.Field(v.Field).Name()
+			}
+		}
+	case *ssa.Call:
+		if v.Call.IsInvoke() {
+			return v.Call.Method.Name()
+		}
+		if f := v.Call.StaticCallee(); f != nil {
+			return f.Name()
+		}
+	case *ssa.UnOp:
+		if g, ok := v.X.(*ssa.Global); ok && v.Op == token.MUL {
+			return g.Name()
+		}
+	case *ssa.Alloc:
+		return v.Comment
+	case *ssa.Phi:
+		return v.Comment
+	}
+	return ""
+}
+
+// propagate taints the result of instr if it is derived from the tainted value v.
+func (t *taintAnalysis) propagate(instr ssa.Instruction, v ssa.Value, mark func(ssa.Value, string)) {
+	name := t.sources[v]
+	switch instr := instr.(type) {
+	case *ssa.BinOp:
+		if !isComparison(instr.Op) {
+			mark(instr, name)
+		}
+	case *ssa.Index:
+		if instr.X == v {
+			mark(instr, name)
+		}
+	case *ssa.IndexAddr:
+		if instr.X == v {
+			mark(instr, name)
+		}
+	case *ssa.Lookup:
+		if instr.X == v {
+			mark(instr, name)
+		}
+	case *ssa.Slice:
+		if instr.X == v {
+			mark(instr, name)
+		}
+	case *ssa.UnOp, *ssa.Convert, *ssa.ChangeType, *ssa.ChangeInterface, *ssa.MakeInterface,
+		*ssa.MultiConvert, *ssa.SliceToArrayPointer, *ssa.TypeAssert, *ssa.Extract, *ssa.Phi:
+		mark(instr.(ssa.Value), name)
+	case *ssa.Call:
+		if t.propagatesThrough(&instr.Call) {
+			mark(instr, name)
+		}
+	case *ssa.Store:
+		if instr.Val == v {
+			mark(rootAddr(instr.Addr), name)
+		}
+	case *ssa.MapUpdate:
+		if instr.Value == v {
+			mark(instr.Map, name)
+		}
+	}
+}
+
+// propagatesThrough reports whether the result of the call carries the taint of its arguments.
+func (t *taintAnalysis) propagatesThrough(c *ssa.CallCommon) bool {
+	if b, ok := c.Value.(*ssa.Builtin); ok {
+		return b.Name() == "append"
+	}
+
+	var obj types.Object
+	if c.IsInvoke() {
+		obj = c.Method
+	} else if f := c.StaticCallee(); f != nil {
+		obj = f.Object()
+	}
+	return obj != nil && obj.Pkg() != nil && t.packages[obj.Pkg().Path()]
+}
+
+func isComparison(op token.Token) bool {
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return true
+	}
+	return false
+}
+
+// rootAddr returns the variable that contains the element or field at addr.
+func rootAddr(addr ssa.Value) ssa.Value {
+	for {
+		switch a := addr.(type) {
+		case *ssa.IndexAddr:
+			addr = a.X
+		case *ssa.FieldAddr:
+			addr = a.X
+		default:
+			return addr
+		}
+	}
+}
+
+// callArgs returns the receiver of c and the SSA values of the n arguments
+// written in the source. The elements of an implicit variadic slice are
+// found among the stores to its backing array.
+func callArgs(c *ssa.CallCommon, n int, ellipsis bool) (ssa.Value, []ssa.Value) {
+	sig := c.Signature()
+	params := c.Args
+
+	var recv ssa.Value
+	if c.IsInvoke() {
+		recv = c.Value
+	} else if len(params) > sig.Params().Len() {
+		recv, params = params[0], params[1:]
+	}
+
+	args := make([]ssa.Value, n)
+	fixed := len(params)
+	if sig.Variadic() && !ellipsis {
+		fixed--
+	}
+	copy(args, params[:min(fixed, n)])
+	if fixed < 0 || fixed >= len(params) {
+		return recv, args
+	}
+
+	s, ok := params[fixed].(*ssa.Slice)
+	if !ok {
+		return recv, args
+	}
+	alloc, ok := s.X.(*ssa.Alloc)
+	if !ok {
+		return recv, args
+	}
+	for _, ref := range *alloc.Referrers() {
+		ia, ok := ref.(*ssa.IndexAddr)
+		if !ok {
+			continue
+		}
+		k, ok := ia.Index.(*ssa.Const)
+		if !ok {
+			continue
+		}
+		i := fixed + int(k.Int64())
+		for _, r := range *ia.Referrers() {
+			if st, ok := r.(*ssa.Store); ok && st.Addr == ia && i < n {
+				args[i] = st.Val
+			}
+		}
+	}
+	return recv, args
+}
+
+// checkSensitiveFlow reports log call arguments that carry a tainted value.
+func checkSensitiveFlow(ctx *ruleContext, call *LogCall) {
+	if call.Kind != KindMessage && call.Kind != KindSend {
+		return
+	}
+
+	t := ctx.taintAnalysis()
+	c := t.calls[call.Call.Lparen]
+	if c == nil {
+		return
+	}
+
+	recv, args := callArgs(c, len(call.Call.Args), call.Call.Ellipsis.IsValid())
+	if name, ok := t.sources[recv]; ok && recv != nil {
+		pos := call.Call.Pos()
+		if sel, ok := ast.Unparen(call.Call.Fun).(*ast.SelectorExpr); ok {
+			pos = sel.Sel.Pos()
+		}
+		ctx.reportf(pos, "log attribute should not contain sensitive data from %q", name)
+	}
+
+	for i, arg := range call.Call.Args {
+		name, ok := t.sources[args[i]]
+		if !ok || args[i] == nil {
+			continue
+		}
+		if arg == call.Message {
+			ctx.reportf(arg.Pos(), "log message should not contain sensitive data from %q", name)
+		} else {
+			ctx.reportf(arg.Pos(), "log attribute should not contain sensitive data from %q", name)
+		}
+	}
+}
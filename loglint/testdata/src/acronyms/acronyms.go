@@ -0,0 +1,18 @@
+package acronyms
+
+import "log/slog"
+
+func tests() {
+	slog.Info("HTTP server started")
+	slog.Info("ID mismatch")
+	slog.Info("IDs reloaded")
+	slog.Info("JWT expired")
+	slog.Info("Kafka consumer ready")
+	slog.Info("HTTP/2 enabled") // want `log message should not contain special characters or emoji`
+
+	slog.Info("FTP server started") // want `log message should start with a lowercase letter`
+	slog.Info("TSVs exported")      // want `log message should start with a lowercase letter`
+	slog.Info("HTTPServer started") // want `log message should start with a lowercase letter`
+	slog.Info("Kafkaesque delay")   // want `log message should start with a lowercase letter`
+	slog.Info("A request failed")   // want `log message should start with a lowercase letter`
+}
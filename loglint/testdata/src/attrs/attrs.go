@@ -0,0 +1,48 @@
+package attrs
+
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/zap"
+)
+
+func slogAttrs(ctx context.Context, logger *slog.Logger, pw, user string) {
+	slog.Info("user logged in", "password", pw)                       // want `log field should not contain sensitive data`
+	slog.Info("user logged in", "user", user, "api_key", user)        // want `log field should not contain sensitive data`
+	slog.InfoContext(ctx, "user logged in", "token", pw)              // want `log field should not contain sensitive data`
+	logger.Warn("user logged in", "secret", pw)                       // want `log field should not contain sensitive data`
+	logger.Log(ctx, slog.LevelInfo, "user logged in", "password", pw) // want `log field should not contain sensitive data`
+
+	slog.Info("user logged in", slog.String("api_key", pw))                          // want `log field should not contain sensitive data`
+	slog.Info("user logged in", slog.Any("credentials", user))                       // want `log field should not contain sensitive data`
+	logger.LogAttrs(ctx, slog.LevelInfo, "user logged in", slog.String("token", pw)) // want `log field should not contain sensitive data`
+	slog.Info("user logged in", slog.Group("auth", "user", user, "password", pw))    // want `log field should not contain sensitive data`
+
+	slog.With("token", pw).Info("user logged in")      // want `log field should not contain sensitive data`
+	logger.With("password", pw).Info("user logged in") // want `log field should not contain sensitive data`
+
+	// constant values and non-sensitive keys are fine
+	slog.Info("user logged in", "password", "***")
+	slog.Info("user logged in", "user", user)
+	slog.Info("user logged in", slog.String("token", "redacted"))
+	slog.Info("user logged in", slog.Group("credentials", "user", user))
+
+	args := []any{"password", pw}
+	slog.Info("user logged in", args...)
+}
+
+func zapFields(logger *zap.Logger, pw, user string) {
+	logger.Info("user logged in", zap.String("token", pw))       // want `log field should not contain sensitive data`
+	logger.Info("user logged in", zap.Any("password", pw))       // want `log field should not contain sensitive data`
+	logger.With(zap.String("secret", pw)).Info("user logged in") // want `log field should not contain sensitive data`
+
+	logger.Info("user logged in", zap.String("user", user), zap.Namespace("credentials"))
+	logger.Info("user logged in", zap.String("password", "***"))
+
+	sugar := logger.Sugar()
+	sugar.Infow("user logged in", "password", pw)                          // want `log field should not contain sensitive data`
+	sugar.Infow("user logged in", "user", user, zap.String("api_key", pw)) // want `log field should not contain sensitive data`
+	sugar.With("token", pw).Infow("user logged in")                        // want `log field should not contain sensitive data`
+	sugar.Infow("user logged in", "user", user)
+}
@@ -0,0 +1,37 @@
+package constantmessage
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type request struct {
+	ID string
+}
+
+const prefix = "request "
+
+func tests(logger *zap.Logger, id string, count int, req request, names []string) {
+	slog.Info("user " + id)                                   // want `log message should be constant, not built with concatenation`
+	slog.Info("user "+id+" logged in", "count", count)        // want `log message should be constant, not built with concatenation`
+	slog.Info(prefix + req.ID + " done")                      // want `log message should be constant, not built with concatenation`
+	slog.Info(fmt.Sprintf("user %s has %d items", id, count)) // want `log message should be constant, not built with fmt.Sprintf`
+	slog.Info(fmt.Sprint("user ", id))                        // want `log message should be constant, not built with fmt.Sprint`
+	slog.Info("users " + strings.Join(names, " "))            // want `log message should be constant, not built with concatenation`
+	slog.Info(strings.Join(names, " "))                       // want `log message should be constant, not built with strings.Join`
+	logger.Info("user " + id)                                 // want `log message should be constant, not built with concatenation`
+	logger.Sugar().Info("user " + id)                         // want `log message should be constant, not built with concatenation`
+	log.Print("user " + id)                                   // want `log message should be constant, not built with concatenation`
+
+	slog.Info("user logged in", "id", id)
+	slog.Info(prefix + "done")
+	logger.Info("user logged in", zap.String("id", id))
+}
+
+func logf(format string, args ...any) {
+	slog.Info(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,37 @@
+package constants
+
+import (
+	"log/slog"
+
+	"constants/messages"
+
+	"go.uber.org/zap"
+)
+
+type Message string
+
+const msgStart = "Starting!"
+
+const (
+	msgStopped         = "stopped!"
+	msgDone    Message = "Done"
+	prefix             = "Request "
+	msgFailed          = prefix + "failed"
+	pwPrefix           = "user password "
+)
+
+func tests(logger *zap.Logger, pw string) {
+	slog.Info(msgStart)                    // want `log message should start with a lowercase letter` `log message should not contain special characters or emoji`
+	logger.Info(msgStart)                  // want `log message should start with a lowercase letter` `log message should not contain special characters or emoji`
+	slog.Info(msgStopped)                  // want `log message should not contain special characters or emoji`
+	slog.Info(string(msgDone))             // want `log message should start with a lowercase letter`
+	slog.Info(prefix + "done")             // want `log message should start with a lowercase letter`
+	slog.Info(msgFailed)                   // want `log message should start with a lowercase letter`
+	slog.Info(messages.Started)            // want `log message should start with a lowercase letter`
+	slog.Info("status " + messages.Failed) // want `log message should be in English only`
+	slog.Info(pwPrefix + pw)               // want `log message should not contain sensitive data`
+	slog.Info(pwPrefix + "hidden")
+
+	const msgLocal = "Local message"
+	slog.Info(msgLocal) // want `log message should start with a lowercase letter`
+}
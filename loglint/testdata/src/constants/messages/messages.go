@@ -0,0 +1,6 @@
+package messages
+
+const (
+	Started = "Server started"
+	Failed  = "запуск не удался"
+)
@@ -0,0 +1,22 @@
+package customloggers
+
+import (
+	"context"
+	"log/slog"
+
+	"example.com/logging"
+)
+
+func customTests(ctx context.Context, logger *logging.Logger, audit *logging.Audit) {
+	logger.Notice(ctx, "Starting server") // want `log message should start with a lowercase letter`
+	logger.Alert(ctx, "disk full!")       // want `log message should not contain special characters or emoji`
+	logging.Event("startup", "запуск")    // want `log message should be in English only`
+	logger.Notice(ctx, "starting server")
+
+	// methods and receivers that are not configured are ignored
+	logger.Trace(ctx, "Starting server")
+	audit.Notice(ctx, "Starting server")
+
+	// built-in loggers are still checked
+	slog.Info("Starting server") // want `log message should start with a lowercase letter`
+}
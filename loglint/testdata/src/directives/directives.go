@@ -0,0 +1,38 @@
+//loglint:file-ignore englishonly messages are parsed by the support team
+
+package directives
+
+import (
+	"log/slog"
+
+	"github.com/rs/zerolog/log"
+)
+
+func tests() {
+	// file-level directive
+	slog.Info("запуск сервера")
+
+	// directive at the end of the line
+	slog.Info("Starting server") //loglint:ignore lowercase kept for log parsers
+
+	// directive on the preceding line
+	//loglint:ignore lowercase,nospecial kept for log parsers
+	slog.Info("Server started!")
+
+	// only the listed rules are ignored
+	//loglint:ignore lowercase kept for log parsers
+	slog.Info("Server started!") // want `log message should not contain special characters or emoji`
+
+	// the directive does not apply to the line after a trailing directive
+	slog.Info("starting server") //loglint:ignore lowercase nothing to ignore // want `unused loglint directive for rule "lowercase"`
+	slog.Info("Starting server") // want `log message should start with a lowercase letter`
+
+	// malformed directives
+	slog.Info("Starting server") //loglint:ignore lowercase // want `loglint directive should explain why the finding is ignored`
+	slog.Info("starting server") //loglint:ignore nosuchrule typo // want `unknown rule "nosuchrule" in loglint directive`
+	slog.Info("starting server") //loglint:ignore // want `loglint directive should list the rules to ignore`
+	slog.Info("starting server") //loglint:ignored is not a directive
+
+	// directives for disabled rules are not reported as unused
+	log.Info().Send() //loglint:ignore zerologsend sending without a message is fine here
+}
@@ -0,0 +1,39 @@
+package duplicatekeys
+
+import (
+	"log/slog"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+const userKey = "user"
+
+func tests(logger *slog.Logger, zl *zap.Logger, a, b string) {
+	logger.With("req_id", a).Info("request done", "req_id", b)             // want `duplicate log key "req_id"`
+	slog.Info("request done", "user", a, "user", b)                        // want `duplicate log key "user"`
+	slog.Info("request done", userKey, a, slog.String("user", b))          // want `duplicate log key "user"`
+	slog.With("user", a).With("req_id", b).Info("request done", "user", a) // want `duplicate log key "user"`
+	slog.With("user", a).With("user", b).Info("request done")              // want `duplicate log key "user"`
+	logger.With("user", a, "user", b).Info("request done")                 // want `duplicate log key "user"`
+
+	zl.Info("request done", zap.String("user", a), zap.String("user", b))      // want `duplicate log key "user"`
+	zl.With(zap.String("user", a)).Info("request done", zap.String("user", b)) // want `duplicate log key "user"`
+	zl.Sugar().With("user", a).Infow("request done", "user", b)                // want `duplicate log key "user"`
+	zl.With(zap.String("user", a), zap.String("user", b)).Info("request done") // want `duplicate log key "user"`
+	zl.With(zap.String("user", a)).With(zap.String("user", b)).Info("x")       // want `duplicate log key "user"`
+
+	logrus.WithField("user", a).WithField("user", b).Info("request done")                 // want `duplicate log key "user"`
+	logrus.WithFields(logrus.Fields{"user": a}).WithField("user", b).Info("request done") // want `duplicate log key "user"`
+
+	// groups start a new namespace
+	logger.WithGroup("request").Info("request done", "user", a)
+	logger.With("user", a).WithGroup("request").Info("request done", "user", b)
+	slog.Info("request done", "user", a, slog.Group("request", "user", b))
+
+	// keys set on a stored logger are not tracked
+	l := logger.With("user", a)
+	l.Info("request done", "user", b)
+
+	slog.Info("request done", "user", a, "req_id", b)
+}
@@ -0,0 +1,15 @@
+package logging
+
+import "context"
+
+type Logger struct{}
+
+func (l *Logger) Notice(ctx context.Context, msg string) {}
+func (l *Logger) Alert(ctx context.Context, msg string)  {}
+func (l *Logger) Trace(ctx context.Context, msg string)  {}
+
+type Audit struct{}
+
+func (a *Audit) Notice(ctx context.Context, msg string) {}
+
+func Event(name, msg string) {}
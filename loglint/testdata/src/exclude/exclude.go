@@ -0,0 +1,7 @@
+package exclude
+
+import "log/slog"
+
+func run() {
+	slog.Info("Starting server") // want `log message should start with a lowercase letter`
+}
@@ -0,0 +1,13 @@
+package exclude
+
+import (
+	"log/slog"
+	"testing"
+)
+
+// test files are only checked by the rules in exclude.test_rules.
+func TestRun(t *testing.T) {
+	token := "t"
+	slog.Info("Running test!")
+	slog.Info("token " + token) // want `log message should not contain sensitive data`
+}
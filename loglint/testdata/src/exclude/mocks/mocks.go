@@ -0,0 +1,8 @@
+package mocks
+
+import "log/slog"
+
+// files under exclude.paths are not checked.
+func Mock() {
+	slog.Info("Mock called!")
+}
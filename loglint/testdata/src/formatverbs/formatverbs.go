@@ -0,0 +1,30 @@
+package formatverbs
+
+import (
+	"context"
+	"log/slog"
+
+	"go.uber.org/zap"
+)
+
+type request struct {
+	ID string
+}
+
+func tests(ctx context.Context, logger *zap.Logger, name string, ms int, req request) {
+	slog.Info("user %s logged in", name)                       // want `log message has formatting directive %s, but Info does not format its arguments` `log value has no key`
+	slog.InfoContext(ctx, "request %s took %d ms", req.ID, ms) // want `log message has formatting directive %s, but InfoContext does not format its arguments` `log key should be a constant`
+	slog.Info("user id %d", ms, "name", name)                  // want `log message has formatting directive %d, but Info does not format its arguments` `log key should be a string, not int`
+	slog.Info("took %d ms")                                    // want `log message has formatting directive %d, but Info does not format its arguments`
+	slog.Info("user %s logged in", name+"x")                   // want `log message has formatting directive %s, but Info does not format its arguments` `log value has no key`
+	slog.Info("user %[1]s logged in", name)                    // want `log message has formatting directive %\[1\]s, but Info does not format its arguments` `log value has no key`
+	logger.Info("took %d ms", zap.Int("ms", ms))               // want `log message has formatting directive %d, but Info does not format its arguments`
+
+	sugar := logger.Sugar()
+	sugar.Info("user %s logged in", name)  // want `log message has formatting directive %s, but Info does not format its arguments`
+	sugar.Infow("user %s logged in", name) // want `log message has formatting directive %s, but Infow does not format its arguments`
+	sugar.Infof("user %s logged in", name)
+
+	slog.Info("user logged in", "name", name)
+	slog.Info("upload is 100% complete") // want `log message should not contain special characters or emoji`
+}
@@ -0,0 +1,18 @@
+package log
+
+import "github.com/rs/zerolog"
+
+var Logger = zerolog.New()
+
+func Trace() *zerolog.Event                        { return Logger.Trace() }
+func Debug() *zerolog.Event                        { return Logger.Debug() }
+func Info() *zerolog.Event                         { return Logger.Info() }
+func Warn() *zerolog.Event                         { return Logger.Warn() }
+func Error() *zerolog.Event                        { return Logger.Error() }
+func Err(err error) *zerolog.Event                 { return Logger.Err(err) }
+func Fatal() *zerolog.Event                        { return Logger.Fatal() }
+func Panic() *zerolog.Event                        { return Logger.Panic() }
+func Log() *zerolog.Event                          { return Logger.Log() }
+func WithLevel(level zerolog.Level) *zerolog.Event { return Logger.WithLevel(level) }
+func Print(v ...interface{})                       { Logger.Print(v...) }
+func Printf(format string, v ...interface{})       { Logger.Printf(format, v...) }
@@ -0,0 +1,181 @@
+package logrus
+
+import "context"
+
+type Level uint32
+
+const (
+	PanicLevel Level = iota
+	FatalLevel
+	ErrorLevel
+	WarnLevel
+	InfoLevel
+	DebugLevel
+	TraceLevel
+)
+
+type Fields map[string]interface{}
+
+type FieldLogger interface {
+	WithField(key string, value interface{}) *Entry
+	WithFields(fields Fields) *Entry
+	WithError(err error) *Entry
+
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Printf(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Warningf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Panicf(format string, args ...interface{})
+
+	Debug(args ...interface{})
+	Info(args ...interface{})
+	Print(args ...interface{})
+	Warn(args ...interface{})
+	Warning(args ...interface{})
+	Error(args ...interface{})
+	Fatal(args ...interface{})
+	Panic(args ...interface{})
+
+	Debugln(args ...interface{})
+	Infoln(args ...interface{})
+	Println(args ...interface{})
+	Warnln(args ...interface{})
+	Warningln(args ...interface{})
+	Errorln(args ...interface{})
+	Fatalln(args ...interface{})
+	Panicln(args ...interface{})
+}
+
+type Ext1FieldLogger interface {
+	FieldLogger
+	Tracef(format string, args ...interface{})
+	Trace(args ...interface{})
+	Traceln(args ...interface{})
+}
+
+type Logger struct{}
+
+func New() *Logger { return &Logger{} }
+
+func (l *Logger) WithField(key string, value interface{}) *Entry { return &Entry{} }
+func (l *Logger) WithFields(fields Fields) *Entry                { return &Entry{} }
+func (l *Logger) WithError(err error) *Entry                     { return &Entry{} }
+func (l *Logger) WithContext(ctx context.Context) *Entry         { return &Entry{} }
+
+func (l *Logger) Log(level Level, args ...interface{})                 {}
+func (l *Logger) Logf(level Level, format string, args ...interface{}) {}
+func (l *Logger) Logln(level Level, args ...interface{})               {}
+
+func (l *Logger) Tracef(format string, args ...interface{})   {}
+func (l *Logger) Debugf(format string, args ...interface{})   {}
+func (l *Logger) Infof(format string, args ...interface{})    {}
+func (l *Logger) Printf(format string, args ...interface{})   {}
+func (l *Logger) Warnf(format string, args ...interface{})    {}
+func (l *Logger) Warningf(format string, args ...interface{}) {}
+func (l *Logger) Errorf(format string, args ...interface{})   {}
+func (l *Logger) Fatalf(format string, args ...interface{})   {}
+func (l *Logger) Panicf(format string, args ...interface{})   {}
+
+func (l *Logger) Trace(args ...interface{})   {}
+func (l *Logger) Debug(args ...interface{})   {}
+func (l *Logger) Info(args ...interface{})    {}
+func (l *Logger) Print(args ...interface{})   {}
+func (l *Logger) Warn(args ...interface{})    {}
+func (l *Logger) Warning(args ...interface{}) {}
+func (l *Logger) Error(args ...interface{})   {}
+func (l *Logger) Fatal(args ...interface{})   {}
+func (l *Logger) Panic(args ...interface{})   {}
+
+func (l *Logger) Traceln(args ...interface{})   {}
+func (l *Logger) Debugln(args ...interface{})   {}
+func (l *Logger) Infoln(args ...interface{})    {}
+func (l *Logger) Println(args ...interface{})   {}
+func (l *Logger) Warnln(args ...interface{})    {}
+func (l *Logger) Warningln(args ...interface{}) {}
+func (l *Logger) Errorln(args ...interface{})   {}
+func (l *Logger) Fatalln(args ...interface{})   {}
+func (l *Logger) Panicln(args ...interface{})   {}
+
+type Entry struct {
+	Logger *Logger
+	Data   Fields
+}
+
+func NewEntry(logger *Logger) *Entry { return &Entry{Logger: logger} }
+
+func (e *Entry) WithField(key string, value interface{}) *Entry { return e }
+func (e *Entry) WithFields(fields Fields) *Entry                { return e }
+func (e *Entry) WithError(err error) *Entry                     { return e }
+func (e *Entry) WithContext(ctx context.Context) *Entry         { return e }
+
+func (e *Entry) Log(level Level, args ...interface{})                 {}
+func (e *Entry) Logf(level Level, format string, args ...interface{}) {}
+func (e *Entry) Logln(level Level, args ...interface{})               {}
+
+func (e *Entry) Tracef(format string, args ...interface{})   {}
+func (e *Entry) Debugf(format string, args ...interface{})   {}
+func (e *Entry) Infof(format string, args ...interface{})    {}
+func (e *Entry) Printf(format string, args ...interface{})   {}
+func (e *Entry) Warnf(format string, args ...interface{})    {}
+func (e *Entry) Warningf(format string, args ...interface{}) {}
+func (e *Entry) Errorf(format string, args ...interface{})   {}
+func (e *Entry) Fatalf(format string, args ...interface{})   {}
+func (e *Entry) Panicf(format string, args ...interface{})   {}
+
+func (e *Entry) Trace(args ...interface{})   {}
+func (e *Entry) Debug(args ...interface{})   {}
+func (e *Entry) Info(args ...interface{})    {}
+func (e *Entry) Print(args ...interface{})   {}
+func (e *Entry) Warn(args ...interface{})    {}
+func (e *Entry) Warning(args ...interface{}) {}
+func (e *Entry) Error(args ...interface{})   {}
+func (e *Entry) Fatal(args ...interface{})   {}
+func (e *Entry) Panic(args ...interface{})   {}
+
+func (e *Entry) Traceln(args ...interface{})   {}
+func (e *Entry) Debugln(args ...interface{})   {}
+func (e *Entry) Infoln(args ...interface{})    {}
+func (e *Entry) Println(args ...interface{})   {}
+func (e *Entry) Warnln(args ...interface{})    {}
+func (e *Entry) Warningln(args ...interface{}) {}
+func (e *Entry) Errorln(args ...interface{})   {}
+func (e *Entry) Fatalln(args ...interface{})   {}
+func (e *Entry) Panicln(args ...interface{})   {}
+
+func WithField(key string, value interface{}) *Entry { return &Entry{} }
+func WithFields(fields Fields) *Entry                { return &Entry{} }
+func WithError(err error) *Entry                     { return &Entry{} }
+func WithContext(ctx context.Context) *Entry         { return &Entry{} }
+
+func Tracef(format string, args ...interface{})   {}
+func Debugf(format string, args ...interface{})   {}
+func Infof(format string, args ...interface{})    {}
+func Printf(format string, args ...interface{})   {}
+func Warnf(format string, args ...interface{})    {}
+func Warningf(format string, args ...interface{}) {}
+func Errorf(format string, args ...interface{})   {}
+func Fatalf(format string, args ...interface{})   {}
+func Panicf(format string, args ...interface{})   {}
+
+func Trace(args ...interface{})   {}
+func Debug(args ...interface{})   {}
+func Info(args ...interface{})    {}
+func Print(args ...interface{})   {}
+func Warn(args ...interface{})    {}
+func Warning(args ...interface{}) {}
+func Error(args ...interface{})   {}
+func Fatal(args ...interface{})   {}
+func Panic(args ...interface{})   {}
+
+func Traceln(args ...interface{})   {}
+func Debugln(args ...interface{})   {}
+func Infoln(args ...interface{})    {}
+func Println(args ...interface{})   {}
+func Warnln(args ...interface{})    {}
+func Warningln(args ...interface{}) {}
+func Errorln(args ...interface{})   {}
+func Fatalln(args ...interface{})   {}
+func Panicln(args ...interface{})   {}
@@ -4,13 +4,13 @@ type Field struct{}
 
 type Logger struct{}
 
-func (l *Logger) Debug(msg string, fields ...Field) {}
-func (l *Logger) Info(msg string, fields ...Field)  {}
-func (l *Logger) Warn(msg string, fields ...Field)  {}
-func (l *Logger) Error(msg string, fields ...Field) {}
+func (l *Logger) Debug(msg string, fields ...Field)  {}
+func (l *Logger) Info(msg string, fields ...Field)   {}
+func (l *Logger) Warn(msg string, fields ...Field)   {}
+func (l *Logger) Error(msg string, fields ...Field)  {}
 func (l *Logger) DPanic(msg string, fields ...Field) {}
-func (l *Logger) Panic(msg string, fields ...Field) {}
-func (l *Logger) Fatal(msg string, fields ...Field) {}
+func (l *Logger) Panic(msg string, fields ...Field)  {}
+func (l *Logger) Fatal(msg string, fields ...Field)  {}
 
 type SugaredLogger struct{}
 
@@ -19,4 +19,24 @@ func (s *SugaredLogger) Infow(msg string, keysAndValues ...interface{})  {}
 func (s *SugaredLogger) Warnw(msg string, keysAndValues ...interface{})  {}
 func (s *SugaredLogger) Errorw(msg string, keysAndValues ...interface{}) {}
 
-func String(key string, val string) Field { return Field{} }
+func String(key string, val string) Field       { return Field{} }
+func Int(key string, val int) Field             { return Field{} }
+func Any(key string, value interface{}) Field   { return Field{} }
+func Reflect(key string, val interface{}) Field { return Field{} }
+func Namespace(key string) Field                { return Field{} }
+func Error(err error) Field                     { return Field{} }
+
+func (l *Logger) With(fields ...Field) *Logger { return l }
+func (l *Logger) Sugar() *SugaredLogger        { return &SugaredLogger{} }
+
+func (s *SugaredLogger) With(args ...interface{}) *SugaredLogger { return s }
+
+func (s *SugaredLogger) Debug(args ...interface{}) {}
+func (s *SugaredLogger) Info(args ...interface{})  {}
+func (s *SugaredLogger) Warn(args ...interface{})  {}
+func (s *SugaredLogger) Error(args ...interface{}) {}
+
+func (s *SugaredLogger) Debugf(template string, args ...interface{}) {}
+func (s *SugaredLogger) Infof(template string, args ...interface{})  {}
+func (s *SugaredLogger) Warnf(template string, args ...interface{})  {}
+func (s *SugaredLogger) Errorf(template string, args ...interface{}) {}
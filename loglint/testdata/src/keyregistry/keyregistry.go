@@ -0,0 +1,22 @@
+package keyregistry
+
+import (
+	"log/slog"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func tests(logger *zap.Logger, userID string, d time.Duration, attempt int, value any) {
+	slog.Info("request done", "user_id", userID, "duration", d)
+	slog.Info("request done", "duration", d.String()) // want `log key "duration" should have a value of type time.Duration, not string`
+	slog.Info("request done", "user", userID)         // want `log key "user" is not in the key registry`
+	slog.Info("request done", slog.Int("attempt", attempt))
+	slog.Info("request done", slog.String("attempt", "3")) // want `log key "attempt" should have a value of type int, not string`
+	slog.Info("request done", slog.Group("request", "user_id", userID))
+	slog.Info("request done", slog.Group("http", "user_id", userID)) // want `log key "http" is not in the key registry`
+	slog.Info("request done", "attempt", 3, "request", value, "duration", value)
+
+	logger.Info("request done", zap.String("user_id", userID), zap.Int("retries", attempt)) // want `log key "retries" is not in the key registry`
+	logger.Sugar().Infow("request done", "attempt", int64(attempt))                         // want `log key "attempt" should have a value of type int, not int64`
+}
@@ -0,0 +1,26 @@
+package keystyle
+
+import (
+	"log/slog"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+const requestKey = "requestID"
+
+func tests(logger *zap.Logger, id int, name string) {
+	slog.Info("user created", "userID", id)                        // want `log key "userID" should be snake_case`
+	slog.Info("user created", "user_id", id, "userName", name)     // want `log key "userName" should be snake_case`
+	slog.Info("user created", slog.Int("userID", id))              // want `log key "userID" should be snake_case`
+	slog.Info("user created", slog.Group("httpRequest", "id", id)) // want `log key "httpRequest" should be snake_case`
+	slog.With("user-name", name).Info("user created")              // want `log key "user-name" should be snake_case`
+	slog.Info("request done", requestKey, id)                      // want `log key "requestID" should be snake_case`
+
+	logger.Info("user created", zap.Int("userID", id))  // want `log key "userID" should be snake_case`
+	logger.Sugar().Infow("user created", "userID", id)  // want `log key "userID" should be snake_case`
+	logrus.WithField("userID", id).Info("user created") // want `log key "userID" should be snake_case`
+
+	slog.Info("user created", "user_id", id, "http.status_code", 200)
+	logger.Info("user created", zap.String("name", name))
+}
@@ -0,0 +1,35 @@
+package keyvalue
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+type request struct {
+	ID string
+}
+
+const userKey = "user"
+
+func tests(ctx context.Context, logger *slog.Logger, id int, name string, key string, req request) {
+	slog.Info("user created", "user", id, "orphan")                          // want `log key "orphan" has no value`
+	slog.Info("user created", id)                                            // want `log key should be a string, not int`
+	slog.Info("user created", "user", name, name)                            // want `log value has no key`
+	slog.Info("user created", key, name)                                     // want `log key should be a constant`
+	slog.Info("request done", req.ID)                                        // want `log value has no key`
+	slog.InfoContext(ctx, "user created", 42)                                // want `log key should be a string, not int`
+	logger.With("user").Info("user created")                                 // want `log key "user" has no value`
+	slog.Info("user created", slog.Group("request", "id", req.ID, "orphan")) // want `log key "orphan" has no value`
+
+	err := errors.New("failed")
+	slog.Error("request failed", err) // want `log key should be a string, not error`
+
+	slog.Info("user created", "user", id, slog.String("name", name), "request", req.ID)
+	slog.Info("user created", userKey, id)
+	logger.With("user", id).Info("user created")
+	logger.LogAttrs(ctx, slog.LevelInfo, "user created", slog.Int("user", id))
+
+	args := []any{"user", id, "orphan"}
+	slog.Info("user created", args...)
+}
@@ -0,0 +1,71 @@
+package logrustest
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+)
+
+func packageTests() {
+	// rule 1: log message should start with a lowercase letter
+	logrus.Info("Starting server")       // want `log message should start with a lowercase letter`
+	logrus.Errorf("Failed to connect")   // want `log message should start with a lowercase letter`
+	logrus.Warningln("Disk almost full") // want `log message should start with a lowercase letter`
+	logrus.Info("starting server")
+
+	// rule 2: log message should be in English only
+	logrus.Debug("запуск сервера") // want `log message should be in English only`
+	logrus.Debug("starting server")
+
+	// rule 3: log message should not contain special characters or emoji
+	logrus.Print("server started!") // want `log message should not contain special characters or emoji`
+	logrus.Print("server started")
+
+	// rule 4: log message should not contain sensitive data
+	password := "secret123"
+	logrus.Info("user password " + password) // want `log message should not contain sensitive data`
+	logrus.Info("user authenticated")
+}
+
+func loggerTests(logger *logrus.Logger) {
+	logger.Info("Starting server") // want `log message should start with a lowercase letter`
+	logger.Traceln("done!")        // want `log message should not contain special characters or emoji`
+	logger.Info("starting server")
+
+	// check Log msg index
+	logger.Log(logrus.ErrorLevel, "Failed to connect") // want `log message should start with a lowercase letter`
+	logger.Logf(logrus.InfoLevel, "failed to connect")
+}
+
+func entryTests(logger *logrus.Logger) {
+	entry := logrus.NewEntry(logger)
+	entry.Errorf("Failed to connect") // want `log message should start with a lowercase letter`
+	entry.Warn("запуск")              // want `log message should be in English only`
+
+	// chained entries
+	logger.WithField("user", "alice").Warn("Access denied")       // want `log message should start with a lowercase letter`
+	logrus.WithError(errors.New("boom")).Error("request failed!") // want `log message should not contain special characters or emoji`
+	logger.WithField("user", "alice").Info("access granted")
+}
+
+func fieldLoggerTests(fl logrus.FieldLogger, ext logrus.Ext1FieldLogger) {
+	fl.Info("Starting server")   // want `log message should start with a lowercase letter`
+	fl.Warnf("disk full!")       // want `log message should not contain special characters or emoji`
+	ext.Trace("Tracing request") // want `log message should start with a lowercase letter`
+	ext.Debugln("tracing request")
+}
+
+func fieldTests(logger *logrus.Logger, token string) {
+	// sensitive keys with non-constant values
+	password := "secret123"
+	logrus.WithField("password", password).Info("user logged in") // want `log field should not contain sensitive data`
+	logger.WithField("api_key", token).Debug("request sent")      // want `log field should not contain sensitive data`
+	logger.WithFields(logrus.Fields{
+		"user":  "alice",
+		"token": token, // want `log field should not contain sensitive data`
+	}).Info("user logged in")
+
+	// constant values and regular keys are fine
+	logrus.WithField("password", "***").Info("user logged in")
+	logger.WithField("user", token).Info("user logged in")
+}
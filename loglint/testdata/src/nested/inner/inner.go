@@ -0,0 +1,11 @@
+package inner
+
+import "log/slog"
+
+func run(password, pin string) {
+	slog.Info("запуск сервера")
+	slog.Info("Starting server")
+	slog.Info("server started!") // want `warning: log message should not contain special characters or emoji`
+	slog.Info("password " + password)
+	slog.Info("pin " + pin) // want `log message should not contain sensitive data`
+}
@@ -0,0 +1,10 @@
+package nested
+
+import "log/slog"
+
+func run(password string) {
+	slog.Info("запуск сервера")
+	slog.Info("Starting server")      // want `log message should start with a lowercase letter`
+	slog.Info("server started!")      // want `warning: log message should not contain special characters or emoji`
+	slog.Info("password " + password) // want `log message should not contain sensitive data`
+}
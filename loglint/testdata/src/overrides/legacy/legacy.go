@@ -0,0 +1,12 @@
+package legacy
+
+import "log/slog"
+
+// legacy packages may log in any language and only treat a PIN as sensitive.
+func legacy(password, pin string) {
+	slog.Info("запуск сервера")
+	slog.Info("Starting server") // want `log message should start with a lowercase letter`
+	slog.Info("password " + password)
+	slog.Info("pin " + pin)      // want `log message should not contain sensitive data`
+	slog.Info("server started!") // want `warning: log message should not contain special characters or emoji`
+}
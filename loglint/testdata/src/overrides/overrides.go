@@ -0,0 +1,10 @@
+package overrides
+
+import "log/slog"
+
+func defaults(password string) {
+	slog.Info("Starting server")      // want `log message should start with a lowercase letter`
+	slog.Info("запуск сервера")       // want `log message should be in English only`
+	slog.Info("server started!")      // want `warning: log message should not contain special characters or emoji`
+	slog.Info("password " + password) // want `log message should not contain sensitive data`
+}
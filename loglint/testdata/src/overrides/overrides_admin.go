@@ -0,0 +1,9 @@
+package overrides
+
+import "log/slog"
+
+// admin files do not check lowercase and report special characters as info.
+func admin() {
+	slog.Info("Starting admin server")
+	slog.Info("admin started!") // want `info: log message should not contain special characters or emoji`
+}
@@ -0,0 +1,53 @@
+package printf
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+)
+
+type ID int
+
+func (id ID) String() string { return "id" }
+
+type Point struct {
+	X, Y int
+}
+
+func tests(logger *zap.Logger, name string, count int, ratio float64, ok bool, id ID, p Point, ids []int, raw []byte, d time.Duration, args []any) {
+	sugar := logger.Sugar()
+	sugar.Infof("user %s has %d items", name, count)
+	sugar.Infof("user %s has %d items", name)  // want `log format "user %s has %d items" reads arg #2, but call has 1 args`
+	sugar.Infof("user %s loaded", name, count) // want `log format "user %s loaded" reads 1 args, but call has 2 args`
+	sugar.Infof("user %d loaded", name)        // want `log format %d has arg name of wrong type string`
+	sugar.Infof("ratio %d", ratio)             // want `log format %d has arg ratio of wrong type float64`
+	sugar.Infof("ratio %.2f and count %x", ratio, count)
+	sugar.Infof("enabled %t", count) // want `log format %t has arg count of wrong type int`
+	sugar.Infof("user %s loaded", id)
+	sugar.Infof("request failed with %s", errors.New("timeout"))
+	sugar.Infof("point %d", p)
+	sugar.Infof("point %s", p) // want `log format %s has arg p of wrong type Point`
+	sugar.Infof("ids %d and raw %s", ids, raw)
+	sugar.Infof("took %s", d)
+	sugar.Infof("value %v of type %T", p, p)
+	sugar.Infof("value %y", count) // want `log format "value %y" has unknown verb %y`
+	sugar.Infof("user %[2]s has %[1]d items", count, name)
+	sugar.Infof("user %[2]s loaded", name) // want `log format "user %\[2\]s loaded" reads arg #2, but call has 1 args`
+	sugar.Infof("value %[0]d", count)      // want `log format "value %\[0\]d" has invalid argument index for %d`
+	sugar.Infof("progress %d%%", count)    // want `log message should not contain special characters or emoji`
+	sugar.Infof("items %*d", 4, count)
+	sugar.Infof("user %s loaded", args...)
+
+	log.Printf("listening on port %d", name) // want `log format %d has arg name of wrong type string`
+	log.Printf("listening on port %d", count)
+	logrus.Infof("user %s logged in", name, ok) // want `log format "user %s logged in" reads 1 args, but call has 2 args`
+
+	// format verbs are not special characters
+	sugar.Infof("user %s has %d items", name, count)
+	sugar.Infof("Started %s", name)  // want `log message should start with a lowercase letter`
+	sugar.Infof("started %s!", name) // want `log message should not contain special characters or emoji`
+	sugar.Infof("%s started", name)
+}
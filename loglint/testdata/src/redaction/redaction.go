@@ -0,0 +1,74 @@
+package redaction
+
+import (
+	"log/slog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type Credentials struct {
+	User string
+	Key  string `log:"redact"`
+}
+
+type Account struct {
+	ID    int
+	Creds Credentials
+}
+
+type Session struct {
+	ID      string
+	Cookie  string `sensitive:"true"`
+	Comment string `sensitive:"false"`
+}
+
+type User struct {
+	Name     string
+	Password string
+}
+
+// Node is recursive and has no sensitive fields.
+type Node struct {
+	Name     string
+	Parent   *Node
+	Children []*Node
+}
+
+type SafeUser struct {
+	Name     string
+	Password string
+}
+
+func (u SafeUser) LogValue() slog.Value {
+	return slog.StringValue(u.Name)
+}
+
+type SafeSession struct {
+	Token string
+}
+
+func (s *SafeSession) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("token", "***")
+	return nil
+}
+
+func tests(logger *zap.Logger, account Account, session *Session, user User, node *Node, safe SafeUser, safeSession *SafeSession) {
+	slog.Info("account loaded", slog.Any("account", account)) // want `log value of type Account should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Creds.Key`
+	slog.Info("session loaded", "session", session)           // want `log value of type \*Session should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Cookie`
+	slog.Info("users loaded", "users", []User{user})          // want `log value of type \[\]User should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Password`
+	logger.Info("user loaded", zap.Any("user", user))         // want `log value of type User should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Password`
+	logger.Info("user loaded", zap.Reflect("user", &user))    // want `log value of type \*User should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Password`
+
+	sugar := logger.Sugar()
+	sugar.Infof("user %s loaded with %v", user.Name, user)  // want `log value of type User should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Password`
+	sugar.Infof("user %[2]s loaded with %+[1]v", user, "x") // want `log value of type User should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Password`
+	sugar.Infof("user loaded with %[0]v", user)             // want `log format "user loaded with %\[0\]v" has invalid argument index for %v`
+	sugar.Infow("session loaded", "session", session)       // want `log value of type \*Session should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Cookie`
+
+	slog.Info("node loaded", "node", node)
+	slog.Info("user loaded", "user", safe)
+	slog.Info("user loaded", "user", &safe)
+	logger.Info("session loaded", zap.Any("session", safeSession))
+	sugar.Infof("user %s loaded", user.Name)
+}
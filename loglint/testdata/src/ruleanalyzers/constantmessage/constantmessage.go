@@ -0,0 +1,12 @@
+package constantmessage
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// only constantmessage diagnostics are reported by the constantmessage analyzer
+func tests(id int) {
+	slog.Info(fmt.Sprintf("user %d", id)) // want `log message should be constant, not built with fmt.Sprintf`
+	slog.Info("Запуск сервера!")          // other rules are not reported
+}
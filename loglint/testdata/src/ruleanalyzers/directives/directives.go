@@ -0,0 +1,11 @@
+package directives
+
+import "log/slog"
+
+// only directive diagnostics are reported by the directives analyzer
+func tests() {
+	slog.Info("Starting server") //loglint:ignore lowercase kept for log parsers
+	slog.Info("Server started!") //loglint:ignore lowercase kept for log parsers
+	slog.Info("starting server") //loglint:ignore lowercase nothing to ignore // want `unused loglint directive for rule "lowercase"`
+	slog.Info("Starting server") //loglint:ignore lowercase // want `loglint directive should explain why the finding is ignored`
+}
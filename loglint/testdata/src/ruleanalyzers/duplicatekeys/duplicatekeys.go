@@ -0,0 +1,9 @@
+package duplicatekeys
+
+import "log/slog"
+
+// only duplicatekeys diagnostics are reported by the duplicatekeys analyzer
+func tests(id int) {
+	slog.Info("user logged in", "user", id, "user", id) // want `duplicate log key "user"`
+	slog.Info("Запуск сервера!")                        // other rules are not reported
+}
@@ -0,0 +1,9 @@
+package englishonly
+
+import "log/slog"
+
+// only englishonly diagnostics are reported by the englishonly analyzer
+func tests() {
+	slog.Info("запуск сервера")  // want `log message should be in English only`
+	slog.Info("Server started!") // other rules are not reported
+}
@@ -0,0 +1,9 @@
+package formatverbs
+
+import "log/slog"
+
+// only formatverbs diagnostics are reported by the formatverbs analyzer
+func tests(name string) {
+	slog.Info("user %s logged in", name) // want `log message has formatting directive %s, but Info does not format its arguments`
+	slog.Info("Запуск сервера!")         // other rules are not reported
+}
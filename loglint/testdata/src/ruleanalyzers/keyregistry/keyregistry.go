@@ -0,0 +1,10 @@
+package keyregistry
+
+import "log/slog"
+
+// only keyregistry diagnostics are reported by the keyregistry analyzer
+func tests(id int) {
+	slog.Info("user logged in", "user_id", id) // want `log key "user_id" should have a value of type string, not int`
+	slog.Info("user logged in", "user", id)    // want `log key "user" is not in the key registry`
+	slog.Info("Запуск сервера!")               // other rules are not reported
+}
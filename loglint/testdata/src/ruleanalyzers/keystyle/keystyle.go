@@ -0,0 +1,9 @@
+package keystyle
+
+import "log/slog"
+
+// only keystyle diagnostics are reported by the keystyle analyzer
+func tests(id int) {
+	slog.Info("user logged in", "userID", id) // want `log key "userID" should be snake_case`
+	slog.Info("Запуск сервера!")              // other rules are not reported
+}
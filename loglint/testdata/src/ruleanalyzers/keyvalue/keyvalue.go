@@ -0,0 +1,9 @@
+package keyvalue
+
+import "log/slog"
+
+// only keyvalue diagnostics are reported by the keyvalue analyzer
+func tests(id int) {
+	slog.Info("user logged in", id) // want `log key should be a string, not int`
+	slog.Info("Запуск сервера!")    // other rules are not reported
+}
@@ -0,0 +1,9 @@
+package lowercase
+
+import "log/slog"
+
+// only lowercase diagnostics are reported by the lowercase analyzer
+func tests() {
+	slog.Info("Starting server") // want `log message should start with a lowercase letter`
+	slog.Info("запуск сервера!") // other rules are not reported
+}
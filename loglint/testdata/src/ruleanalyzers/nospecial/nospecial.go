@@ -0,0 +1,9 @@
+package nospecial
+
+import "log/slog"
+
+// only nospecial diagnostics are reported by the nospecial analyzer
+func tests() {
+	slog.Info("server started!") // want `log message should not contain special characters or emoji`
+	slog.Info("Запуск сервера")  // other rules are not reported
+}
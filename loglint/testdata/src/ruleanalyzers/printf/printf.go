@@ -0,0 +1,10 @@
+package printf
+
+import "log"
+
+// only printf diagnostics are reported by the printf analyzer
+func tests(count int) {
+	log.Printf("starting server on port %s", count) // want `log format %s has arg count of wrong type int`
+	log.Printf("starting server on port %d")        // want `log format "starting server on port %d" reads arg #1, but call has 0 args`
+	log.Printf("Запуск сервера %d!", count)         // other rules are not reported
+}
@@ -0,0 +1,14 @@
+package redaction
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string
+}
+
+// only redaction diagnostics are reported by the redaction analyzer
+func tests(user User) {
+	slog.Info("user logged in", "user", user) // want `log value of type User should implement slog.LogValuer or zapcore.ObjectMarshaler to redact sensitive field Password`
+	slog.Info("Запуск сервера!")              // other rules are not reported
+}
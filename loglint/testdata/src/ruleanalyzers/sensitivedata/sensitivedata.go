@@ -0,0 +1,14 @@
+package sensitivedata
+
+import (
+	"log/slog"
+
+	"github.com/sirupsen/logrus"
+)
+
+// only sensitivedata diagnostics are reported by the sensitivedata analyzer
+func tests(password string) {
+	slog.Info("user password " + password)                     // want `log message should not contain sensitive data`
+	logrus.WithField("token", password).Info("user logged in") // want `log field should not contain sensitive data`
+	slog.Info("Запуск сервера!")                               // other rules are not reported
+}
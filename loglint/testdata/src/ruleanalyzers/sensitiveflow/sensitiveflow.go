@@ -0,0 +1,15 @@
+package sensitiveflow
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string
+}
+
+// only sensitiveflow diagnostics are reported by the sensitiveflow analyzer
+func tests(user User) {
+	secret := user.Password
+	slog.Info("user logged in", "value", secret) // want `log attribute should not contain sensitive data from "secret"`
+	slog.Info("Запуск сервера!")                 // other rules are not reported
+}
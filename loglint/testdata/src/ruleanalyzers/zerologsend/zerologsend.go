@@ -0,0 +1,13 @@
+package zerologsend
+
+import (
+	"log/slog"
+
+	"github.com/rs/zerolog/log"
+)
+
+// only zerologsend diagnostics are reported by the zerologsend analyzer
+func tests() {
+	log.Info().Send()            // want `zerolog info event should have a message`
+	slog.Info("Запуск сервера!") // other rules are not reported
+}
@@ -0,0 +1,78 @@
+package sensitiveflow
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+type User struct {
+	Name     string
+	Password string
+}
+
+type Store interface {
+	Token() string
+}
+
+var apiToken string
+
+func getSecret() string { return "" }
+
+func readInput() string { return "" }
+
+func mask(s string) string { return "***" }
+
+func fields(user *User, logger *zap.Logger) {
+	slog.Info("login for " + user.Password) // want `log message should not contain sensitive data from "Password"`
+	slog.Info("login", "user", user.Name)
+	slog.Info("login", "pass", user.Password)               // want `log attribute should not contain sensitive data from "Password"`
+	logger.Info("login", zap.String("pass", user.Password)) // want `log attribute should not contain sensitive data from "Password"`
+}
+
+func values(pwd string, store Store) {
+	msg := fmt.Sprintf("login with %s", pwd)
+	slog.Info(msg) // want `log message should not contain sensitive data from "pwd"`
+
+	secret := readInput()
+	slog.Info("request", "value", strings.ToUpper(secret)) // want `log attribute should not contain sensitive data from "secret"`
+
+	slog.Info("secret loaded", "value", getSecret())       // want `log attribute should not contain sensitive data from "getSecret"`
+	slog.Info("token loaded", "value", store.Token())      // want `log attribute should not contain sensitive data from "Token"`
+	slog.Info("token loaded", "value", apiToken)           // want `log attribute should not contain sensitive data from "apiToken"`
+	slog.Info("token length", "value", len(store.Token())) // length of a secret is not a secret
+
+	logger := slog.With("pwd", pwd) // want `log field should not contain sensitive data`
+	logger.Info("user logged in")   // want `log attribute should not contain sensitive data from "pwd"`
+
+	slog.Info("login", "value", mask(pwd))
+	slog.Info("password reset")
+
+	if pwd == "" {
+		slog.Info("empty input")
+	}
+}
+
+type Config struct {
+	Password string
+	Value    string
+}
+
+func selectors(cfg *Config, conf Config) {
+	secret := cfg.Password
+	slog.Info("config loaded", "value", secret) // want `log attribute should not contain sensitive data from "secret"`
+
+	token := conf.Value
+	slog.Info("config loaded", "value", token) // want `log attribute should not contain sensitive data from "token"`
+
+	value := cfg.Value
+	slog.Info("config loaded", "value", value)
+}
+
+func closures(token string) {
+	func() {
+		slog.Info("received " + token) // want `log message should not contain sensitive data from "token"`
+	}()
+}
@@ -0,0 +1,22 @@
+package specialchars
+
+import (
+	"log/slog"
+
+	"go.uber.org/zap"
+)
+
+func tests(logger *zap.Logger, n int) {
+	slog.Info("retrying request (attempt limit reached)")
+	slog.Info("cache hit-rate low")
+	slog.Info("user_id mismatch")
+	slog.Info("warning: config /etc/app.yml not found")
+	slog.Info("upgraded to v1.2")
+	slog.Info("deployed \U0001F680")
+
+	slog.Info("server started!")              // want `log message should not contain special characters or emoji`
+	slog.Info("loading...")                   // want `log message should not contain special characters or emoji`
+	slog.Info("Retrying (attempt limit) #2")  // want `log message should start with a lowercase letter` `log message should not contain special characters or emoji`
+	logger.Sugar().Infof("hit-rate: %d%%", n) // want `log message should not contain special characters or emoji`
+	logger.Sugar().Infof("100%% done!")       // want `log message should not contain special characters or emoji`
+}
@@ -0,0 +1,49 @@
+package stdlog
+
+import (
+	"log"
+	"os"
+)
+
+func packageTests() {
+	// rule 1: log message should start with a lowercase letter
+	log.Print("Starting server")         // want `log message should start with a lowercase letter`
+	log.Printf("Listening on port 8080") // want `log message should start with a lowercase letter`
+	log.Println("Server started")        // want `log message should start with a lowercase letter`
+	log.Print("starting server")
+
+	// rule 2: log message should be in English only
+	log.Println("запуск сервера") // want `log message should be in English only`
+	log.Println("starting server")
+
+	// rule 3: log message should not contain special characters or emoji
+	log.Print("server started!") // want `log message should not contain special characters or emoji`
+	log.Print("server started")
+
+	// rule 4: log message should not contain sensitive data
+	password := "secret123"
+	log.Println("user password " + password) // want `log message should not contain sensitive data`
+	log.Println("user authenticated")
+
+	// fatal and panic variants
+	log.Fatalf("Failed to start")   // want `log message should start with a lowercase letter`
+	log.Panicln("Unexpected state") // want `log message should start with a lowercase letter`
+	log.Fatal("failed to start")
+}
+
+func loggerTests() {
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+
+	logger.Print("Starting server") // want `log message should start with a lowercase letter`
+	logger.Printf("запуск")         // want `log message should be in English only`
+	logger.Println("done!")         // want `log message should not contain special characters or emoji`
+	logger.Print("starting server")
+
+	// check Output msg index
+	logger.Output(2, "Failed to connect") // want `log message should start with a lowercase letter`
+	log.Output(2, "failed to connect")
+
+	// non-logging methods are ignored
+	logger.SetPrefix("Prefix: ")
+	log.SetPrefix("Prefix: ")
+}
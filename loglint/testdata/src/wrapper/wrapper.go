@@ -0,0 +1,44 @@
+package wrapper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	logger *zap.Logger
+}
+
+func Info(msg string) { // want Info:"logWrapper\\(0\\)"
+	slog.Info(msg)
+}
+
+func (s *Service) logf(format string, args ...any) { // want logf:"logWrapper\\(0, format\\)"
+	slog.Info(fmt.Sprintf(format, args...))
+}
+
+func (s *Service) Warn(ctx context.Context, msg string, fields ...zap.Field) { // want Warn:"logWrapper\\(1\\)"
+	s.logger.Warn(msg, fields...)
+}
+
+// Notice wraps another wrapper declared later in the package.
+func Notice(msg string) { // want Notice:"logWrapper\\(0\\)"
+	debug(msg)
+}
+
+func debug(msg string) { // want debug:"logWrapper\\(0\\)"
+	slog.Debug(msg)
+}
+
+// not wrappers: the parameter is modified or not used as the message
+func prefixed(msg string) {
+	msg = "prefix " + msg
+	slog.Info(msg)
+}
+
+func attribute(user string) {
+	slog.Info("user logged in", "user", user)
+}
@@ -0,0 +1,32 @@
+package wrapperlocal
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type Service struct{}
+
+func (s *Service) logf(format string, args ...any) {
+	slog.Info(fmt.Sprintf(format, args...))
+}
+
+// notice wraps another wrapper declared later in the package.
+func notice(msg string) {
+	debug(msg)
+}
+
+func debug(msg string) {
+	slog.Debug(msg)
+}
+
+func prefixed(msg string) {
+	msg = "prefix " + msg
+	slog.Info(msg)
+}
+
+func (s *Service) Run() {
+	s.logf("Starting service") // want `log message should start with a lowercase letter`
+	notice("запуск сервиса")   // want `log message should be in English only`
+	prefixed("Starting service")
+}
@@ -0,0 +1,15 @@
+package wrapperuse
+
+import (
+	"context"
+
+	"wrapper"
+)
+
+func useWrappers(ctx context.Context, svc *wrapper.Service, password string) {
+	wrapper.Info("Starting server")             // want `log message should start with a lowercase letter`
+	svc.Warn(ctx, "server started!")            // want `log message should not contain special characters or emoji`
+	wrapper.Notice("user password " + password) // want `log message should not contain sensitive data`
+	wrapper.Info("starting server")
+	svc.Warn(ctx, "server started")
+}
@@ -0,0 +1,19 @@
+package zerologsend
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func sendTests(logger zerolog.Logger) {
+	log.Info().Str("user", "alice").Send()     // want `zerolog info event should have a message`
+	log.Debug().Send()                         // want `zerolog debug event should have a message`
+	logger.Err(errors.New("boom")).Send()      // want `zerolog error event should have a message`
+	logger.WithLevel(zerolog.WarnLevel).Send() // want `zerolog event should have a message`
+	log.Info().Str("user", "alice").Msg("user logged in")
+
+	event := logger.Warn()
+	event.Send() // want `zerolog event should have a message`
+}
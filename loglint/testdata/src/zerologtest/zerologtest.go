@@ -0,0 +1,46 @@
+package zerologtest
+
+import (
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func globalTests() {
+	// rule 1: log message should start with a lowercase letter
+	log.Info().Msg("Starting server")                      // want `log message should start with a lowercase letter`
+	log.Error().Str("host", "db").Msg("Failed to connect") // want `log message should start with a lowercase letter`
+	log.Info().Msg("starting server")
+
+	// rule 2: log message should be in English only
+	log.Debug().Int("port", 8080).Msg("запуск сервера") // want `log message should be in English only`
+	log.Debug().Msg("starting server")
+
+	// rule 3: log message should not contain special characters or emoji
+	log.Warn().Msgf("disk full!") // want `log message should not contain special characters or emoji`
+	log.Warn().Msgf("disk full")
+
+	// rule 4: log message should not contain sensitive data
+	password := "secret123"
+	log.Info().Msg("user password " + password) // want `log message should not contain sensitive data`
+	log.Info().Msg("user authenticated")
+
+	// print methods
+	log.Print("Starting server") // want `log message should start with a lowercase letter`
+	log.Printf("starting server")
+
+	// Send is not reported unless enabled in the config
+	log.Info().Str("user", "alice").Send()
+}
+
+func loggerTests(logger zerolog.Logger) {
+	logger.Info().Msg("Starting server")                          // want `log message should start with a lowercase letter`
+	logger.Err(errors.New("boom")).Msg("request failed!")         // want `log message should not contain special characters or emoji`
+	logger.WithLevel(zerolog.InfoLevel).Msg("Level set manually") // want `log message should start with a lowercase letter`
+	logger.Print("done!")                                         // want `log message should not contain special characters or emoji`
+
+	// events stored in variables are still checked
+	event := logger.Warn().Str("user", "alice")
+	event.Msg("Access denied") // want `log message should start with a lowercase letter`
+}
@@ -0,0 +1,154 @@
+package loglint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// wrapperFact marks a function that passes one of its parameters
+// to a logger as the log message. Format is set when the parameter
+// is used as a printf-style format.
+type wrapperFact struct {
+	MsgIndex int
+	Format   bool
+}
+
+func (*wrapperFact) AFact() {}
+
+func (f *wrapperFact) String() string {
+	if f.Format {
+		return fmt.Sprintf("logWrapper(%d, format)", f.MsgIndex)
+	}
+	return fmt.Sprintf("logWrapper(%d)", f.MsgIndex)
+}
+
+var sprintFuncs = map[string]bool{
+	"Sprint": true, "Sprintf": true, "Sprintln": true,
+}
+
+// exportWrapperFacts exports a wrapperFact for every function of the package that
+// forwards a parameter straight into the message argument of a logger.
+// Wrappers of wrappers declared in the same package are found by repeating
+// the search until no new wrappers appear.
+func exportWrapperFacts(pass *analysis.Pass, loggers loggerSet) {
+	var decls []*ast.FuncDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Body != nil {
+				decls = append(decls, fd)
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, decl := range decls {
+			fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok || pass.ImportObjectFact(fn, new(wrapperFact)) {
+				continue
+			}
+			if fact, ok := forwardedParam(pass, loggers, decl, fn); ok {
+				pass.ExportObjectFact(fn, fact)
+				changed = true
+			}
+		}
+	}
+}
+
+// forwardedParam returns the fact for the parameter of fn that is passed
+// unchanged as the message of a log call in its body.
+func forwardedParam(pass *analysis.Pass, loggers loggerSet, decl *ast.FuncDecl, fn *types.Func) (*wrapperFact, bool) {
+	params := fn.Type().(*types.Signature).Params()
+	if params.Len() == 0 {
+		return nil, false
+	}
+
+	index := make(map[*types.Var]int, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		index[params.At(i)] = i
+	}
+	assigned := assignedVars(pass.TypesInfo, decl.Body)
+
+	var result *wrapperFact
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if result != nil {
+			return false
+		}
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		callee := calleeFunc(pass.TypesInfo, call)
+		if callee == nil || callee.Pkg() == nil {
+			return true
+		}
+		args, ok := loggerArgsOf(pass, loggers, callee)
+		if !ok || args.msgIndex >= len(call.Args) {
+			return true
+		}
+
+		param, sprintf := messageVar(pass.TypesInfo, call.Args[args.msgIndex])
+		if i, ok := index[param]; ok && !assigned[param] {
+			result = &wrapperFact{MsgIndex: i, Format: args.format || sprintf}
+		}
+		return true
+	})
+	return result, result != nil
+}
+
+// messageVar returns the variable used as a log message, looking through
+// fmt.Sprint, fmt.Sprintf and fmt.Sprintln calls whose first argument is the variable.
+// sprintf reports whether the variable is the format of fmt.Sprintf.
+func messageVar(info *types.Info, expr ast.Expr) (v *types.Var, sprintf bool) {
+	expr = ast.Unparen(expr)
+	if call, ok := expr.(*ast.CallExpr); ok {
+		fn := calleeFunc(info, call)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" || !sprintFuncs[fn.Name()] || len(call.Args) == 0 {
+			return nil, false
+		}
+		expr = ast.Unparen(call.Args[0])
+		sprintf = fn.Name() == "Sprintf"
+	}
+
+	id, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	v, _ = info.Uses[id].(*types.Var)
+	return v, sprintf
+}
+
+// assignedVars returns the variables that are assigned or have their address taken inside body.
+func assignedVars(info *types.Info, body *ast.BlockStmt) map[*types.Var]bool {
+	assigned := make(map[*types.Var]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		var lhs []ast.Expr
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			lhs = node.Lhs
+		case *ast.IncDecStmt:
+			lhs = []ast.Expr{node.X}
+		case *ast.UnaryExpr:
+			if node.Op == token.AND {
+				lhs = []ast.Expr{node.X}
+			}
+		}
+		for _, e := range lhs {
+			if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+				if v, ok := info.Uses[id].(*types.Var); ok {
+					assigned[v] = true
+				}
+			}
+		}
+		return true
+	})
+	return assigned
+}
@@ -0,0 +1,76 @@
+package loglint
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const zerologPkg = "github.com/rs/zerolog"
+
+// zerologLevelMethods start a new event on zerolog.Logger or in the zerolog/log package.
+var zerologLevelMethods = map[string]string{
+	"Trace": "trace", "Debug": "debug", "Info": "info", "Warn": "warn",
+	"Error": "error", "Err": "error", "Fatal": "fatal", "Panic": "panic",
+	"Log": "", "WithLevel": "",
+}
+
+// isZerologPkg reports whether path is zerolog or its global logger package.
+func isZerologPkg(path string) bool {
+	return path == zerologPkg || path == zerologPkg+"/log"
+}
+
+// isZerologEventMethod reports whether fn is a method of *zerolog.Event.
+func isZerologEventMethod(fn *types.Func) bool {
+	return fn.Pkg() != nil && fn.Pkg().Path() == zerologPkg && receiverName(fn) == "Event"
+}
+
+// zerologLevel walks back up an event chain such as log.Info().Str("k", v)
+// and returns the level of the method that started the event.
+// It returns false if the chain does not start with a known level method.
+func zerologLevel(info *types.Info, expr ast.Expr) (string, bool) {
+	for {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok {
+			return "", false
+		}
+		selector, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", false
+		}
+		fn, ok := info.Uses[selector.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil || !isZerologPkg(fn.Pkg().Path()) {
+			return "", false
+		}
+
+		if isZerologEventMethod(fn) {
+			expr = selector.X
+			continue
+		}
+
+		level, found := zerologLevelMethods[fn.Name()]
+		return level, found
+	}
+}
+
+// checkZerologSend reports an event finished with Send instead of Msg.
+func checkZerologSend(ctx *ruleContext, call *ast.CallExpr) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	level, _ := zerologLevel(ctx.pass.TypesInfo, selector.X)
+	ctx.report(analysis.Diagnostic{
+		Pos:     selector.Sel.Pos(),
+		Message: zerologSendMessage(level),
+	})
+}
+
+// zerologSendMessage returns the diagnostic reported for an event finished with Send.
+func zerologSendMessage(level string) string {
+	if level == "" {
+		return "zerolog event should have a message"
+	}
+	return "zerolog " + level + " event should have a message"
+}
@@ -9,7 +9,7 @@ import (
 type analyzerPlugin struct{}
 
 func (*analyzerPlugin) GetAnalyzers() []*analysis.Analyzer {
-	return []*analysis.Analyzer{loglint.Analyzer}
+	return loglint.Analyzers
 }
 
 // AnalyzerPlugin is the entry point for golangci-lint.